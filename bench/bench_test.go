@@ -0,0 +1,88 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/client"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLatencyStats(t *testing.T) {
+	var durations []time.Duration
+	for i := 100; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+	stats := NewLatencyStats(durations)
+	assert.Equal(t, 100, stats.Count)
+	assert.Equal(t, 1.0, stats.Min)
+	assert.Equal(t, 100.0, stats.Max)
+	assert.Equal(t, 50.0, stats.P50)
+	assert.Equal(t, 90.0, stats.P90)
+	assert.Equal(t, 99.0, stats.P99)
+	assert.Equal(t, 0, NewLatencyStats(nil).Count)
+}
+
+func TestRunAgainstMockNodes(t *testing.T) {
+	var endpoints []client.URL
+	for i := 0; i < 2; i++ {
+		node, err := NewMockNode(50 * time.Millisecond)
+		assert.NoError(t, err)
+		defer node.Close()
+		endpoints = append(endpoints, node.URL)
+	}
+	keys, err := client.DeriveKeys([]byte("bench"), 4)
+	assert.NoError(t, err)
+
+	report, err := Run(context.Background(), Config{
+		Endpoints:    endpoints,
+		Keys:         keys,
+		Contract:     precompile.EvidenceManagementAddress,
+		Func:         "saveEvidence",
+		Params:       TemplateParams("key_{seq}", "value"),
+		TPS:          200,
+		Concurrency:  4,
+		Total:        40,
+		PollInterval: 10 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 40, report.Sent)
+	assert.Equal(t, 40, report.Confirmed)
+	assert.Equal(t, 0, report.SendFailed)
+	assert.Equal(t, 40, report.InclusionLatency.Count)
+	assert.True(t, report.ReceiptLatency.P50 >= report.SendLatency.P50)
+
+	var jsonBuf bytes.Buffer
+	assert.NoError(t, report.WriteJSON(&jsonBuf))
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(jsonBuf.Bytes(), &decoded))
+
+	var csvBuf bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&csvBuf))
+	rows, err := csv.NewReader(&csvBuf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(rows))
+}
+
+func TestRunRejectsConstantFunction(t *testing.T) {
+	node, err := NewMockNode(50 * time.Millisecond)
+	assert.NoError(t, err)
+	defer node.Close()
+	keys, err := client.DeriveKeys([]byte("bench"), 1)
+	assert.NoError(t, err)
+
+	_, err = Run(context.Background(), Config{
+		Endpoints: []client.URL{node.URL},
+		Keys:      keys,
+		Contract:  precompile.EvidenceManagementAddress,
+		Func:      "getEvidence",
+		Params:    ConstParams("key"),
+		Total:     1,
+	})
+	assert.Error(t, err)
+}
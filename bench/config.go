@@ -0,0 +1,104 @@
+package bench
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/client"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+const (
+	defaultReceiptTimeout = 30 * time.Second
+	defaultPollInterval   = 200 * time.Millisecond
+)
+
+// ParamGenerator 根据交易序号生成合约方法的参数
+type ParamGenerator func(seq int) []string
+
+// Config 压测配置
+type Config struct {
+	// 压测的节点，账户按照顺序平均分配到各个节点
+	Endpoints []client.URL
+	// 发送交易的账户，数量不能少于节点数量
+	Keys []*keystore.Key
+	// 账户选择策略
+	Strategy client.PoolStrategy
+
+	// 合约地址或 cns 名字
+	Contract string
+	// abi 文件路径，预编译合约可以为空
+	AbiPath string
+	// 合约类型, wasm 或 evm
+	VmType string
+	// 合约方法，只支持写方法
+	Func   string
+	Params ParamGenerator
+
+	// 每秒发送的交易数，0 表示不限速
+	TPS int
+	// 并发发送的协程数
+	Concurrency int
+	// 发送交易的总数，0 表示一直发送到 Duration 结束
+	Total    int
+	Duration time.Duration
+
+	// 等待回执的超时时间和轮询间隔
+	ReceiptTimeout time.Duration
+	PollInterval   time.Duration
+}
+
+func (cfg *Config) check() error {
+	if len(cfg.Endpoints) == 0 {
+		return errors.New("the endpoints are empty")
+	}
+	if len(cfg.Keys) < len(cfg.Endpoints) {
+		return errors.New("the number of keys must not be less than the number of endpoints")
+	}
+	if cfg.Func == "" {
+		return errors.New("the function name is empty")
+	}
+	if cfg.Total <= 0 && cfg.Duration <= 0 {
+		return errors.New("either total or duration must be set")
+	}
+	if cfg.TPS < 0 || cfg.Concurrency < 0 {
+		return errors.New("tps and concurrency must not be negative")
+	}
+	if cfg.Params == nil {
+		cfg.Params = ConstParams()
+	}
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.ReceiptTimeout == 0 {
+		cfg.ReceiptTimeout = defaultReceiptTimeout
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	return nil
+}
+
+// ConstParams 每笔交易使用相同的参数
+func ConstParams(params ...string) ParamGenerator {
+	return func(seq int) []string {
+		return params
+	}
+}
+
+// TemplateParams 按照模板生成参数，{seq} 替换为交易序号，{rand} 替换为随机数，
+// 例如 TemplateParams("key_{seq}", "value") 生成不重复的存证 key
+func TemplateParams(template ...string) ParamGenerator {
+	return func(seq int) []string {
+		params := make([]string, 0, len(template))
+		for _, t := range template {
+			t = strings.Replace(t, "{seq}", strconv.Itoa(seq), -1)
+			t = strings.Replace(t, "{rand}", strconv.FormatInt(rand.Int63(), 10), -1)
+			params = append(params, t)
+		}
+		return params
+	}
+}
@@ -0,0 +1,216 @@
+package bench
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/client"
+	"github.com/Venachain/client-sdk-go/types"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+)
+
+// MockNode 本地模拟节点，用于在没有链的环境下自测压测工具
+// 接收 eth_sendRawTransaction 的交易，每隔 BlockInterval 打包一次待处理交易
+type MockNode struct {
+	URL client.URL
+
+	// 接收交易的人为延迟
+	SendDelay time.Duration
+
+	listener net.Listener
+	server   *http.Server
+	quit     chan struct{}
+	wg       sync.WaitGroup
+
+	mu          sync.Mutex
+	blockNumber uint64
+	pending     []string
+	txs         map[string]*mockTx
+}
+
+type mockTx struct {
+	hash        string
+	from        string
+	to          string
+	blockNumber *uint64
+	index       int
+}
+
+type mockRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type mockError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// 启动模拟节点，监听本地随机端口
+func NewMockNode(blockInterval time.Duration) (*MockNode, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	p, _ := strconv.ParseUint(port, 10, 64)
+
+	node := &MockNode{
+		URL:      client.NewURL(host, p),
+		listener: listener,
+		quit:     make(chan struct{}),
+		txs:      make(map[string]*mockTx),
+	}
+	node.server = &http.Server{Handler: http.HandlerFunc(node.serveHTTP)}
+
+	node.wg.Add(2)
+	go func() {
+		defer node.wg.Done()
+		_ = node.server.Serve(listener)
+	}()
+	go func() {
+		defer node.wg.Done()
+		node.produceBlocks(blockInterval)
+	}()
+	return node, nil
+}
+
+func (node *MockNode) Close() {
+	close(node.quit)
+	_ = node.server.Close()
+	node.wg.Wait()
+}
+
+// 已接收的交易数
+func (node *MockNode) TxCount() int {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	return len(node.txs)
+}
+
+func (node *MockNode) produceBlocks(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-node.quit:
+			return
+		case <-ticker.C:
+			node.mu.Lock()
+			node.blockNumber++
+			number := node.blockNumber
+			for i, hash := range node.pending {
+				tx := node.txs[hash]
+				tx.blockNumber = &number
+				tx.index = i
+			}
+			node.pending = nil
+			node.mu.Unlock()
+		}
+	}
+}
+
+func (node *MockNode) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var req mockRequest
+	resp := map[string]interface{}{"jsonrpc": "2.0"}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		resp["error"] = mockError{Code: -32700, Message: err.Error()}
+	} else {
+		resp["id"] = req.ID
+		result, err := node.handle(req)
+		if err != nil {
+			resp["error"] = mockError{Code: -32000, Message: err.Error()}
+		} else {
+			resp["result"] = result
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (node *MockNode) handle(req mockRequest) (interface{}, error) {
+	var param string
+	if len(req.Params) > 0 {
+		_ = json.Unmarshal(req.Params[0], &param)
+	}
+
+	switch req.Method {
+	case types.NetVersion:
+		return "300", nil
+	case types.GetblockNumber:
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		return hexutil.EncodeUint64(node.blockNumber), nil
+	case types.SendRawTransaction:
+		if node.SendDelay > 0 {
+			time.Sleep(node.SendDelay)
+		}
+		return node.sendRawTransaction(param)
+	case types.GetTransactionByHash:
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		tx, ok := node.txs[param]
+		if !ok {
+			return nil, nil
+		}
+		result := map[string]interface{}{"hash": tx.hash, "from": tx.from, "to": tx.to, "blockNumber": nil}
+		if tx.blockNumber != nil {
+			result["blockNumber"] = hexutil.EncodeUint64(*tx.blockNumber)
+		}
+		return result, nil
+	case "eth_getTransactionReceipt":
+		node.mu.Lock()
+		defer node.mu.Unlock()
+		tx, ok := node.txs[param]
+		if !ok || tx.blockNumber == nil {
+			return nil, nil
+		}
+		return map[string]interface{}{
+			"transactionHash":  tx.hash,
+			"transactionIndex": hexutil.EncodeUint64(uint64(tx.index)),
+			"blockNumber":      hexutil.EncodeUint64(*tx.blockNumber),
+			"from":             tx.from,
+			"to":               tx.to,
+			"gasUsed":          "0x0",
+			"status":           "0x1",
+			"logs":             []interface{}{},
+		}, nil
+	}
+	return nil, errors.New("the method " + req.Method + " is not supported by the mock node")
+}
+
+func (node *MockNode) sendRawTransaction(rawTx string) (interface{}, error) {
+	raw, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(types.Transaction)
+	if err = rlp.DecodeBytes(raw, tx); err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(types.MakeSigner(tx), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := tx.Hash().Hex()
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	if _, ok := node.txs[hash]; ok {
+		return nil, errors.New("known transaction: " + hash)
+	}
+	mtx := &mockTx{hash: hash, from: from.Hex()}
+	if tx.To() != nil {
+		mtx.to = tx.To().Hex()
+	}
+	node.txs[hash] = mtx
+	node.pending = append(node.pending, hash)
+	return hash, nil
+}
@@ -0,0 +1,204 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Sample 单笔交易的压测数据，时间均从开始发送交易时计算
+type Sample struct {
+	Seq       int           `json:"seq"`
+	Endpoint  string        `json:"endpoint"`
+	From      string        `json:"from"`
+	Hash      string        `json:"hash"`
+	Send      time.Duration `json:"send"`
+	Inclusion time.Duration `json:"inclusion"`
+	Receipt   time.Duration `json:"receipt"`
+	Status    string        `json:"status"`
+	Err       string        `json:"err,omitempty"`
+}
+
+// LatencyStats 延迟的分位数统计，单位为毫秒
+type LatencyStats struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P95   float64 `json:"p95"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max"`
+}
+
+// Report 压测报告
+type Report struct {
+	Start         time.Time `json:"start"`
+	Elapsed       float64   `json:"elapsed"` // 秒
+	Sent          int       `json:"sent"`
+	SendFailed    int       `json:"sendFailed"`
+	Included      int       `json:"included"`
+	Confirmed     int       `json:"confirmed"`
+	Reverted      int       `json:"reverted"`
+	ReceiptFailed int       `json:"receiptFailed"`
+	SendTPS       float64   `json:"sendTps"`
+	ConfirmedTPS  float64   `json:"confirmedTps"`
+
+	SendLatency      LatencyStats   `json:"sendLatency"`
+	InclusionLatency LatencyStats   `json:"inclusionLatency"`
+	ReceiptLatency   LatencyStats   `json:"receiptLatency"`
+	Errors           map[string]int `json:"errors,omitempty"`
+
+	Samples []Sample `json:"-"`
+}
+
+func newReport(start time.Time, elapsed time.Duration, samples []Sample) *Report {
+	report := &Report{
+		Start:   start,
+		Elapsed: elapsed.Seconds(),
+		Errors:  make(map[string]int),
+		Samples: samples,
+	}
+	var send, inclusion, receipt []time.Duration
+	for _, s := range samples {
+		if s.Err != "" {
+			report.Errors[s.Err]++
+		}
+		if s.Hash == "" {
+			report.SendFailed++
+			continue
+		}
+		report.Sent++
+		send = append(send, s.Send)
+		if s.Inclusion > 0 {
+			report.Included++
+			inclusion = append(inclusion, s.Inclusion)
+		}
+		if s.Receipt == 0 {
+			report.ReceiptFailed++
+			continue
+		}
+		report.Confirmed++
+		receipt = append(receipt, s.Receipt)
+		if s.Status != "0x1" {
+			report.Reverted++
+		}
+	}
+	if report.Elapsed > 0 {
+		report.SendTPS = float64(report.Sent) / report.Elapsed
+		report.ConfirmedTPS = float64(report.Confirmed) / report.Elapsed
+	}
+	report.SendLatency = NewLatencyStats(send)
+	report.InclusionLatency = NewLatencyStats(inclusion)
+	report.ReceiptLatency = NewLatencyStats(receipt)
+	return report
+}
+
+// NewLatencyStats 计算延迟的分位数，使用 nearest-rank 算法
+func NewLatencyStats(durations []time.Duration) LatencyStats {
+	stats := LatencyStats{Count: len(durations)}
+	if len(durations) == 0 {
+		return stats
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		}
+		return toMillis(sorted[rank-1])
+	}
+	stats.Min = toMillis(sorted[0])
+	stats.Max = toMillis(sorted[len(sorted)-1])
+	stats.Mean = toMillis(sum / time.Duration(len(sorted)))
+	stats.P50 = percentile(50)
+	stats.P90 = percentile(90)
+	stats.P95 = percentile(95)
+	stats.P99 = percentile(99)
+	return stats
+}
+
+func toMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// WriteJSON 以 json 格式输出报告
+func (r *Report) WriteJSON(w io.Writer) error {
+	reportBytes, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(reportBytes)
+	return err
+}
+
+// WriteCSV 以 csv 格式输出各项延迟的分位数
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"metric", "count", "min_ms", "mean_ms", "p50_ms", "p90_ms", "p95_ms", "p99_ms", "max_ms"}}
+	for _, m := range []struct {
+		name  string
+		stats LatencyStats
+	}{
+		{"send", r.SendLatency},
+		{"inclusion", r.InclusionLatency},
+		{"receipt", r.ReceiptLatency},
+	} {
+		rows = append(rows, []string{
+			m.name,
+			strconv.Itoa(m.stats.Count),
+			formatMillis(m.stats.Min),
+			formatMillis(m.stats.Mean),
+			formatMillis(m.stats.P50),
+			formatMillis(m.stats.P90),
+			formatMillis(m.stats.P95),
+			formatMillis(m.stats.P99),
+			formatMillis(m.stats.Max),
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteSamplesCSV 以 csv 格式输出每笔交易的压测数据
+func (r *Report) WriteSamplesCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"seq", "endpoint", "from", "hash", "send_ms", "inclusion_ms", "receipt_ms", "status", "err"}}
+	for _, s := range r.Samples {
+		rows = append(rows, []string{
+			strconv.Itoa(s.Seq),
+			s.Endpoint,
+			s.From,
+			s.Hash,
+			formatMillis(toMillis(s.Send)),
+			formatMillis(toMillis(s.Inclusion)),
+			formatMillis(toMillis(s.Receipt)),
+			s.Status,
+			s.Err,
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatMillis(ms float64) string {
+	return fmt.Sprintf("%.3f", ms)
+}
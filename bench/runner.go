@@ -0,0 +1,275 @@
+package bench
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/client"
+	"github.com/Venachain/client-sdk-go/common"
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/types"
+	vena_common "github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+// Runner 压测执行器
+type Runner struct {
+	cfg       Config
+	methodAbi *packet.FuncDesc
+	content   packet.ContractContent
+	cns       *packet.Cns
+	to        vena_common.Address
+
+	endpoints []*endpoint
+}
+
+// 每个节点拥有独立的账户池和用于查询的客户端
+type endpoint struct {
+	name   string
+	pool   *client.AccountPool
+	client *client.Client
+}
+
+func NewRunner(ctx context.Context, cfg Config) (*Runner, error) {
+	if err := cfg.check(); err != nil {
+		return nil, err
+	}
+	abiPath := cfg.AbiPath
+	if abiPath == "" {
+		abiPath = cfg.Contract
+	}
+	content, err := client.GenContractContent(abiPath)
+	if err != nil {
+		return nil, err
+	}
+	methodAbi, err := content.GetFuncFromAbi(cfg.Func)
+	if err != nil {
+		return nil, err
+	}
+	cns, to, err := packet.CnsParse(cfg.Contract)
+	if err != nil {
+		return nil, err
+	}
+	r := &Runner{
+		cfg:       cfg,
+		methodAbi: methodAbi,
+		content:   content,
+		cns:       cns,
+		to:        to,
+	}
+
+	// 账户按照顺序平均分配到各个节点，保证同一账户的 nonce 通道只在一个节点上
+	keys := make([][]*keystore.Key, len(cfg.Endpoints))
+	for i, key := range cfg.Keys {
+		keys[i%len(cfg.Endpoints)] = append(keys[i%len(cfg.Endpoints)], key)
+	}
+	for i, url := range cfg.Endpoints {
+		pool, err := client.NewAccountPool(ctx, url, keys[i], cfg.Strategy)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		// 回执延迟与打包延迟使用相同的轮询精度
+		pool.SetReceiptPollInterval(cfg.PollInterval)
+		c, err := client.NewClientWithKey(ctx, url, nil)
+		if err != nil {
+			pool.Close()
+			r.Close()
+			return nil, err
+		}
+		r.endpoints = append(r.endpoints, &endpoint{name: url.GetEndpointAddr(), pool: pool, client: c})
+	}
+
+	// 只支持写方法
+	if _, err = r.makeTxparam(0); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Runner) Close() {
+	for _, e := range r.endpoints {
+		e.pool.Close()
+		e.client.RpcClient.Close()
+	}
+}
+
+func (r *Runner) makeTxparam(seq int) (*common.TxParams, error) {
+	funcArgs, err := r.methodAbi.StringToArgs(r.cfg.Params(seq))
+	if err != nil {
+		return nil, err
+	}
+	dataGenerator := packet.NewContractDataGen(packet.NewData(funcArgs, r.methodAbi), r.content, r.cns.TxType)
+	vmType := "wasm"
+	if r.cfg.VmType == "evm" {
+		vmType = "evm"
+	}
+	dataGenerator.SetInterpreter(vmType, r.cns.Name, r.cns.TxType)
+	if !dataGenerator.GetIsWrite() {
+		return nil, fmt.Errorf("the function %s is constant, only the write functions can be benchmarked", r.methodAbi.Name)
+	}
+	// from 由账户池填写
+	return dataGenerator.MakeTxparamForContract(&vena_common.Address{}, &r.to)
+}
+
+// Run 执行压测，发送完成后等待所有交易的回执并生成报告
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	if r.cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Duration)
+		defer cancel()
+	}
+
+	var (
+		mu       sync.Mutex
+		samples  []Sample
+		trackers sync.WaitGroup
+		workers  sync.WaitGroup
+	)
+	record := func(s Sample) {
+		mu.Lock()
+		samples = append(samples, s)
+		mu.Unlock()
+	}
+
+	start := time.Now()
+	jobs := make(chan int)
+	go r.produce(ctx, jobs)
+
+	for i := 0; i < r.cfg.Concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for seq := range jobs {
+				sample, tx := r.send(ctx, seq)
+				if tx == nil {
+					record(sample)
+					continue
+				}
+				trackers.Add(1)
+				go func(sample Sample, tx *client.PoolTx) {
+					defer trackers.Done()
+					record(r.track(sample, tx))
+				}(sample, tx)
+			}
+		}()
+	}
+	workers.Wait()
+	trackers.Wait()
+
+	return newReport(start, time.Since(start), samples), nil
+}
+
+// produce 按照 TPS 生成交易序号，达到总数或超时后结束
+func (r *Runner) produce(ctx context.Context, jobs chan<- int) {
+	defer close(jobs)
+
+	var tick <-chan time.Time
+	if r.cfg.TPS > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(r.cfg.TPS))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for seq := 0; r.cfg.Total <= 0 || seq < r.cfg.Total; seq++ {
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case jobs <- seq:
+		}
+	}
+}
+
+func (r *Runner) send(ctx context.Context, seq int) (Sample, *client.PoolTx) {
+	e := r.endpoints[seq%len(r.endpoints)]
+	sample := Sample{Seq: seq, Endpoint: e.name}
+
+	txparam, err := r.makeTxparam(seq)
+	if err != nil {
+		sample.Err = err.Error()
+		return sample, nil
+	}
+	begin := time.Now()
+	tx, err := e.pool.Send(ctx, txparam)
+	sample.Send = time.Since(begin)
+	if err != nil {
+		sample.Err = err.Error()
+		return sample, nil
+	}
+	sample.Hash = tx.Hash
+	sample.From = tx.From.Hex()
+	// 以发送开始的时间作为后续延迟的起点
+	tx.SentAt = begin
+	return sample, tx
+}
+
+// track 轮询交易被打包的时间和获取回执的时间
+func (r *Runner) track(sample Sample, tx *client.PoolTx) Sample {
+	e := r.endpoints[sample.Seq%len(r.endpoints)]
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.ReceiptTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+	for sample.Inclusion == 0 {
+		included, err := isIncluded(ctx, e.client, tx.Hash)
+		if err == nil && included {
+			sample.Inclusion = time.Since(tx.SentAt)
+			break
+		}
+		select {
+		case <-ctx.Done():
+			sample.Err = "wait inclusion timeout"
+			// 释放账户池中的待确认计数
+			e.pool.WaitReceipts(ctx, []*client.PoolTx{tx})
+			return sample
+		case <-ticker.C:
+		}
+	}
+
+	receipt := e.pool.WaitReceipts(ctx, []*client.PoolTx{tx})[0]
+	if receipt.Err != nil {
+		sample.Err = "wait receipt timeout"
+		return sample
+	}
+	sample.Receipt = time.Since(tx.SentAt)
+	sample.Status = receipt.Receipt.Status
+	return sample
+}
+
+func isIncluded(ctx context.Context, c *client.Client, hash string) (bool, error) {
+	result, err := c.RpcCall(ctx, types.GetTransactionByHash, hash)
+	if err != nil {
+		return false, err
+	}
+	var tx struct {
+		BlockNumber *string `json:"blockNumber"`
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return false, nil
+	}
+	if err = json.Unmarshal(result, &tx); err != nil {
+		return false, err
+	}
+	return tx.BlockNumber != nil, nil
+}
+
+// Run 使用配置创建执行器并执行压测
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	r, err := NewRunner(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return r.Run(ctx)
+}
@@ -42,21 +42,8 @@ func NewAccountClientWithKey(ctx context.Context, url URL, key *keystore.Key, ad
 }
 
 func (accountClient AccountClient) UserAdd(ctx context.Context, name, phone, email, organization string) (string, error) {
-	userdescinfo := syscontracts.UserDescInfo{}
-	var userinfo syscontracts.UserInfo
 	funcName := "addUser"
-	userdescinfo.Phone = phone
-	userdescinfo.Email = email
-	userdescinfo.Organization = organization
-	desbytes, _ := json.Marshal(userdescinfo)
-	desinfo := string(desbytes)
-
-	userinfo.Address = accountClient.Address
-	userinfo.Name = name
-	userinfo.DescInfo = desinfo
-	bytes, _ := json.Marshal(userinfo)
-	strJson := string(bytes)
-	funcParams := []string{strJson}
+	funcParams := []string{userInfoParam(accountClient.Address, name, phone, email, organization)}
 
 	result, err := accountClient.contractCallWithParams(ctx, funcParams, funcName, precompile.UserManagementAddress)
 	if err != nil {
@@ -66,16 +53,27 @@ func (accountClient AccountClient) UserAdd(ctx context.Context, name, phone, ema
 	return res[0].(string), nil
 }
 
+// addUser 的参数，描述信息为 json 编码的 UserDescInfo
+func userInfoParam(address common_venachain.Address, name, phone, email, organization string) string {
+	userinfo := syscontracts.UserInfo{
+		Address:  address,
+		Name:     name,
+		DescInfo: userDescInfoParam(phone, email, organization),
+	}
+	bytes, _ := json.Marshal(userinfo)
+	return string(bytes)
+}
+
+func userDescInfoParam(phone, email, organization string) string {
+	desbytes, _ := json.Marshal(syscontracts.UserDescInfo{Phone: phone, Email: email, Organization: organization})
+	return string(desbytes)
+}
+
 func (accountClient AccountClient) UserUpdate(ctx context.Context, phone, email, organization string) (string, error) {
 	var funcParams []string
 	funcParams = append(funcParams, accountClient.Address.Hex())
 	funcName := "updateUserDescInfo"
-	userdescinfo := syscontracts.UserDescInfo{}
-	userdescinfo.Phone = phone
-	userdescinfo.Email = email
-	userdescinfo.Organization = organization
-	desbytes, _ := json.Marshal(userdescinfo)
-	funcParams = append(funcParams, string(desbytes))
+	funcParams = append(funcParams, userDescInfoParam(phone, email, organization))
 
 	result, err := accountClient.contractCallWithParams(ctx, funcParams, funcName, precompile.UserManagementAddress)
 	if err != nil {
@@ -0,0 +1,311 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/common"
+	"github.com/Venachain/client-sdk-go/packet"
+	vena_common "github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+	"github.com/pborman/uuid"
+)
+
+// PoolStrategy 账户池选择发送账户的策略
+type PoolStrategy int
+
+const (
+	// RoundRobin 依次轮流使用各个账户
+	RoundRobin PoolStrategy = iota
+	// LeastPending 使用待确认交易最少的账户
+	LeastPending
+)
+
+// 默认的回执轮询间隔
+const receiptPollInterval = 500 * time.Millisecond
+
+// 查询回执连续出错的次数上限，超过后不再等待该交易
+const maxReceiptErrors = 3
+
+// AccountPool 多账户发送池，每个账户拥有独立的 nonce 通道，交易按照策略分发到不同账户发送
+type AccountPool struct {
+	accounts []*poolAccount
+	strategy PoolStrategy
+	next     uint64
+	start    time.Time
+	// 等待回执时的轮询间隔，决定回执延迟统计的精度
+	pollInterval time.Duration
+}
+
+type poolAccount struct {
+	client *Client
+
+	// mu 保证同一账户的 nonce 分配和发送是串行的
+	mu        sync.Mutex
+	nonce     uint64
+	nonceInit bool
+
+	pending       int64
+	sent          uint64
+	sendFailed    uint64
+	confirmed     uint64
+	reverted      uint64
+	receiptFailed uint64
+}
+
+// PoolTx 账户池发送的交易
+type PoolTx struct {
+	Hash   string
+	From   vena_common.Address
+	Nonce  uint64
+	SentAt time.Time
+
+	account *poolAccount
+	// settled 标记交易已经结束等待，保证待确认计数只减少一次
+	settled int32
+}
+
+// PoolReceipt 账户池交易的回执
+type PoolReceipt struct {
+	Tx      *PoolTx
+	Receipt *packet.Receipt
+	Err     error
+	// 从发送到获取回执的时间
+	Latency time.Duration
+}
+
+// AccountStats 单个账户的统计信息
+type AccountStats struct {
+	Address       string  `json:"address"`
+	Sent          uint64  `json:"sent"`
+	SendFailed    uint64  `json:"sendFailed"`
+	Pending       int64   `json:"pending"`
+	Confirmed     uint64  `json:"confirmed"`
+	Reverted      uint64  `json:"reverted"` // 已上链但执行失败的交易数
+	ReceiptFailed uint64  `json:"receiptFailed"`
+	Throughput    float64 `json:"throughput"` // 每秒发送成功的交易数
+}
+
+// 通过 key 列表构建账户池，每个 key 使用 NewClientWithKey 建立独立的连接
+func NewAccountPool(ctx context.Context, url URL, keys []*keystore.Key, strategy PoolStrategy) (*AccountPool, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("the keys of the account pool are empty")
+	}
+	pool := &AccountPool{
+		strategy:     strategy,
+		start:        time.Now(),
+		pollInterval: receiptPollInterval,
+	}
+	for _, key := range keys {
+		client, err := NewClientWithKey(ctx, url, key)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+		pool.accounts = append(pool.accounts, &poolAccount{client: client})
+	}
+	return pool, nil
+}
+
+// 通过多个 keyfile 构建账户池，所有 keyfile 使用相同的密码
+func NewAccountPoolFromFiles(ctx context.Context, url URL, keyfilePaths []string, passphrase string, strategy PoolStrategy) (*AccountPool, error) {
+	keys := make([]*keystore.Key, 0, len(keyfilePaths))
+	for _, path := range keyfilePaths {
+		key, err := NewKey(path, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("load keyfile %s error: %v", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewAccountPool(ctx, url, keys, strategy)
+}
+
+// 由种子确定性地派生 n 个账户的 key，第 i 个私钥为 hash(seed || i)
+func DeriveKeys(seed []byte, n int) ([]*keystore.Key, error) {
+	if len(seed) == 0 {
+		return nil, errors.New("the seed is empty")
+	}
+	keys := make([]*keystore.Key, 0, n)
+	for i := 0; i < n; i++ {
+		d := crypto.Keccak256(seed, big.NewInt(int64(i)).Bytes())
+		priv, err := crypto.ToECDSA(d)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, &keystore.Key{
+			Id:         uuid.NewRandom(),
+			Address:    crypto.PubkeyToAddress(priv.PublicKey),
+			PrivateKey: priv,
+		})
+	}
+	return keys, nil
+}
+
+// 账户池中账户的数量
+func (pool *AccountPool) Size() int {
+	return len(pool.accounts)
+}
+
+// 账户池中账户的地址
+func (pool *AccountPool) Addresses() []vena_common.Address {
+	addresses := make([]vena_common.Address, 0, len(pool.accounts))
+	for _, account := range pool.accounts {
+		addresses = append(addresses, account.client.Key.Address)
+	}
+	return addresses
+}
+
+func (pool *AccountPool) Close() {
+	for _, account := range pool.accounts {
+		account.client.RpcClient.Close()
+	}
+}
+
+func (pool *AccountPool) pick() *poolAccount {
+	if pool.strategy == LeastPending {
+		picked := pool.accounts[0]
+		for _, account := range pool.accounts[1:] {
+			if atomic.LoadInt64(&account.pending) < atomic.LoadInt64(&picked.pending) {
+				picked = account
+			}
+		}
+		return picked
+	}
+	i := atomic.AddUint64(&pool.next, 1) - 1
+	return pool.accounts[i%uint64(len(pool.accounts))]
+}
+
+// 按照策略选择账户发送交易，tx 的 From 和 Nonce 由账户池填写，不会修改传入的 tx
+func (pool *AccountPool) Send(ctx context.Context, tx *common.TxParams) (*PoolTx, error) {
+	account := pool.pick()
+	return account.send(ctx, tx)
+}
+
+func (account *poolAccount) send(ctx context.Context, tx *common.TxParams) (*PoolTx, error) {
+	account.mu.Lock()
+	defer account.mu.Unlock()
+
+	// 链上的 nonce 是随机的，每个账户以随机数为起点依次递增，避免同一账户内的 nonce 重复
+	if !account.nonceInit {
+		account.nonce = common.NewNonce()
+		account.nonceInit = true
+	}
+	txparam := *tx
+	txparam.From = account.client.Key.Address
+	txparam.Nonce = hexutil.EncodeUint64(account.nonce)
+
+	hash, err := account.client.Send(ctx, &txparam, account.client.Key)
+	if err != nil {
+		atomic.AddUint64(&account.sendFailed, 1)
+		return nil, err
+	}
+	account.nonce++
+	atomic.AddUint64(&account.sent, 1)
+	atomic.AddInt64(&account.pending, 1)
+
+	return &PoolTx{
+		Hash:    hash,
+		From:    txparam.From,
+		Nonce:   account.nonce - 1,
+		SentAt:  time.Now(),
+		account: account,
+	}, nil
+}
+
+// 设置等待回执时的轮询间隔，不大于 0 时使用默认的间隔，需要在发送交易前设置
+func (pool *AccountPool) SetReceiptPollInterval(interval time.Duration) {
+	if interval <= 0 {
+		interval = receiptPollInterval
+	}
+	pool.pollInterval = interval
+}
+
+// 并发等待所有交易的回执，返回结果与 txs 的顺序一致
+func (pool *AccountPool) WaitReceipts(ctx context.Context, txs []*PoolTx) []*PoolReceipt {
+	receipts := make([]*PoolReceipt, len(txs))
+	var wg sync.WaitGroup
+	for i, tx := range txs {
+		wg.Add(1)
+		go func(i int, tx *PoolTx) {
+			defer wg.Done()
+			receipts[i] = tx.account.waitReceipt(ctx, tx, pool.pollInterval)
+		}(i, tx)
+	}
+	wg.Wait()
+	return receipts
+}
+
+// 等待交易的回执，回执状态为失败时计入 reverted，查询连续出错或 ctx 结束时计入 receiptFailed
+func (account *poolAccount) waitReceipt(ctx context.Context, tx *PoolTx, interval time.Duration) *PoolReceipt {
+	result := &PoolReceipt{Tx: tx}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	errCount := 0
+	for {
+		receipt, err := account.client.GetTransactionReceipt(tx.Hash)
+		if err != nil {
+			if errCount++; errCount >= maxReceiptErrors {
+				result.Err = fmt.Errorf("get receipt of %s error: %w", tx.Hash, err)
+				account.settle(tx, &account.receiptFailed)
+				return result
+			}
+		} else if receipt != nil {
+			result.Receipt = receipt
+			result.Latency = time.Since(tx.SentAt)
+			if receipt.Parsing().Status != packet.TxReceiptSuccessMsg {
+				account.settle(tx, &account.reverted)
+			} else {
+				account.settle(tx, &account.confirmed)
+			}
+			return result
+		} else {
+			errCount = 0
+		}
+		select {
+		case <-ctx.Done():
+			result.Err = fmt.Errorf("wait receipt of %s error: %w", tx.Hash, ctx.Err())
+			account.settle(tx, &account.receiptFailed)
+			return result
+		case <-ticker.C:
+		}
+	}
+}
+
+// 交易结束等待时减少待确认计数并更新对应的统计，同一笔交易只记录第一次的结果
+func (account *poolAccount) settle(tx *PoolTx, counter *uint64) {
+	if !atomic.CompareAndSwapInt32(&tx.settled, 0, 1) {
+		return
+	}
+	atomic.AddInt64(&account.pending, -1)
+	atomic.AddUint64(counter, 1)
+}
+
+// 各个账户的发送统计
+func (pool *AccountPool) Stats() []AccountStats {
+	elapsed := time.Since(pool.start).Seconds()
+	stats := make([]AccountStats, 0, len(pool.accounts))
+	for _, account := range pool.accounts {
+		sent := atomic.LoadUint64(&account.sent)
+		stat := AccountStats{
+			Address:       account.client.Key.Address.Hex(),
+			Sent:          sent,
+			SendFailed:    atomic.LoadUint64(&account.sendFailed),
+			Pending:       atomic.LoadInt64(&account.pending),
+			Confirmed:     atomic.LoadUint64(&account.confirmed),
+			Reverted:      atomic.LoadUint64(&account.reverted),
+			ReceiptFailed: atomic.LoadUint64(&account.receiptFailed),
+		}
+		if elapsed > 0 {
+			stat.Throughput = float64(sent) / elapsed
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/common"
+	"github.com/Venachain/client-sdk-go/types"
+	vena_common "github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountPool_SendAndWait(t *testing.T) {
+	var mu sync.Mutex
+	nonces := make(map[vena_common.Address][]uint64)
+	server, url := newMockNode(t, map[string]mockHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) interface{} {
+			raw, _ := hexutil.Decode(mockParamString(params, 0))
+			tx := new(types.Transaction)
+			if err := rlp.DecodeBytes(raw, tx); err != nil {
+				return nil
+			}
+			from, _ := types.Sender(types.HomesteadSigner{}, tx)
+			mu.Lock()
+			nonces[from] = append(nonces[from], tx.Nonce())
+			mu.Unlock()
+			return tx.Hash().Hex()
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) interface{} {
+			return map[string]interface{}{
+				"transactionHash": mockParamString(params, 0),
+				"blockNumber":     "0x1",
+				"status":          "0x1",
+			}
+		},
+	})
+	defer server.Close()
+
+	keys, err := DeriveKeys([]byte("account pool"), 3)
+	assert.NoError(t, err)
+	pool, err := NewAccountPool(context.Background(), url, keys, RoundRobin)
+	assert.NoError(t, err)
+	defer pool.Close()
+
+	to := vena_common.HexToAddress("0x0000000000000000000000000000000000000099")
+	var txs []*PoolTx
+	for i := 0; i < 9; i++ {
+		tx, err := pool.Send(context.Background(), &common.TxParams{To: &to, Data: "0x01"})
+		assert.NoError(t, err)
+		txs = append(txs, tx)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipts := pool.WaitReceipts(ctx, txs)
+	for i, r := range receipts {
+		assert.NoError(t, r.Err)
+		assert.Equal(t, txs[i].Hash, r.Receipt.TransactionHash)
+	}
+
+	// 每个账户 3 笔交易，nonce 依次递增
+	assert.Equal(t, 3, len(nonces))
+	for _, lane := range nonces {
+		assert.Equal(t, 3, len(lane))
+		for i := 1; i < len(lane); i++ {
+			assert.Equal(t, lane[i-1]+1, lane[i])
+		}
+	}
+	for _, stat := range pool.Stats() {
+		assert.Equal(t, uint64(3), stat.Sent)
+		assert.Equal(t, uint64(3), stat.Confirmed)
+		assert.Equal(t, int64(0), stat.Pending)
+	}
+}
+
+func TestAccountPool_LeastPending(t *testing.T) {
+	server, url := newMockNode(t, map[string]mockHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) interface{} {
+			raw, _ := hexutil.Decode(mockParamString(params, 0))
+			tx := new(types.Transaction)
+			_ = rlp.DecodeBytes(raw, tx)
+			return tx.Hash().Hex()
+		},
+	})
+	defer server.Close()
+
+	keys, err := DeriveKeys([]byte("account pool"), 2)
+	assert.NoError(t, err)
+	pool, err := NewAccountPool(context.Background(), url, keys, LeastPending)
+	assert.NoError(t, err)
+	defer pool.Close()
+
+	to := vena_common.HexToAddress("0x0000000000000000000000000000000000000099")
+	first, err := pool.Send(context.Background(), &common.TxParams{To: &to})
+	assert.NoError(t, err)
+	second, err := pool.Send(context.Background(), &common.TxParams{To: &to})
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.From, second.From)
+}
+
+func TestAccountPool_ReceiptPollInterval(t *testing.T) {
+	var mu sync.Mutex
+	queries := 0
+	server, url := newMockNode(t, map[string]mockHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) interface{} {
+			raw, _ := hexutil.Decode(mockParamString(params, 0))
+			tx := new(types.Transaction)
+			_ = rlp.DecodeBytes(raw, tx)
+			return tx.Hash().Hex()
+		},
+		// 第三次查询时才有回执
+		"eth_getTransactionReceipt": func(params []json.RawMessage) interface{} {
+			mu.Lock()
+			defer mu.Unlock()
+			if queries++; queries < 3 {
+				return nil
+			}
+			return map[string]interface{}{"transactionHash": mockParamString(params, 0), "blockNumber": "0x1", "status": "0x1"}
+		},
+	})
+	defer server.Close()
+
+	keys, err := DeriveKeys([]byte("account pool"), 1)
+	assert.NoError(t, err)
+	pool, err := NewAccountPool(context.Background(), url, keys, RoundRobin)
+	assert.NoError(t, err)
+	defer pool.Close()
+	pool.SetReceiptPollInterval(10 * time.Millisecond)
+
+	to := vena_common.HexToAddress("0x0000000000000000000000000000000000000099")
+	tx, err := pool.Send(context.Background(), &common.TxParams{To: &to, Data: "0x01"})
+	assert.NoError(t, err)
+	receipt := pool.WaitReceipts(context.Background(), []*PoolTx{tx})[0]
+	assert.NoError(t, receipt.Err)
+	// 默认的 500ms 间隔下至少需要 1s
+	assert.Less(t, int64(receipt.Latency), int64(receiptPollInterval))
+}
+
+func TestAccountPool_ReceiptFailures(t *testing.T) {
+	var mu sync.Mutex
+	var hashes []string
+	queries := make(map[string]int)
+	server, url := newMockNode(t, map[string]mockHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) interface{} {
+			raw, _ := hexutil.Decode(mockParamString(params, 0))
+			tx := new(types.Transaction)
+			_ = rlp.DecodeBytes(raw, tx)
+			mu.Lock()
+			hashes = append(hashes, tx.Hash().Hex())
+			mu.Unlock()
+			return tx.Hash().Hex()
+		},
+		// 第一笔交易执行失败，第二笔交易的回执无法解析，第三笔交易一直没有回执
+		"eth_getTransactionReceipt": func(params []json.RawMessage) interface{} {
+			hash := mockParamString(params, 0)
+			mu.Lock()
+			defer mu.Unlock()
+			queries[hash]++
+			switch hash {
+			case hashes[0]:
+				return map[string]interface{}{"transactionHash": hash, "blockNumber": "0x1", "status": "0x0"}
+			case hashes[1]:
+				return "garbled"
+			}
+			return nil
+		},
+	})
+	defer server.Close()
+
+	keys, err := DeriveKeys([]byte("account pool"), 1)
+	assert.NoError(t, err)
+	pool, err := NewAccountPool(context.Background(), url, keys, RoundRobin)
+	assert.NoError(t, err)
+	defer pool.Close()
+	pool.SetReceiptPollInterval(10 * time.Millisecond)
+
+	to := vena_common.HexToAddress("0x0000000000000000000000000000000000000099")
+	var txs []*PoolTx
+	for i := 0; i < 3; i++ {
+		tx, err := pool.Send(context.Background(), &common.TxParams{To: &to, Data: "0x01"})
+		assert.NoError(t, err)
+		txs = append(txs, tx)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	receipts := pool.WaitReceipts(ctx, txs)
+	assert.NoError(t, receipts[0].Err)
+	assert.Equal(t, "0x0", receipts[0].Receipt.Status)
+	assert.Error(t, receipts[1].Err)
+	assert.Equal(t, maxReceiptErrors, queries[txs[1].Hash])
+	assert.Error(t, receipts[2].Err)
+
+	// 重复等待同一批交易不会重复减少待确认计数
+	pool.WaitReceipts(ctx, txs)
+	stat := pool.Stats()[0]
+	assert.Equal(t, uint64(3), stat.Sent)
+	assert.Equal(t, uint64(0), stat.Confirmed)
+	assert.Equal(t, uint64(1), stat.Reverted)
+	assert.Equal(t, uint64(2), stat.ReceiptFailed)
+	assert.Equal(t, int64(0), stat.Pending)
+}
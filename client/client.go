@@ -8,8 +8,10 @@ import (
 	"math/big"
 
 	"github.com/Venachain/client-sdk-go/common"
+	"github.com/Venachain/client-sdk-go/packet"
 	"github.com/Venachain/client-sdk-go/types"
 	"github.com/Venachain/client-sdk-go/venachain/abi"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
 	"github.com/Venachain/client-sdk-go/venachain/keystore"
 	"github.com/Venachain/client-sdk-go/venachain/rpc"
 )
@@ -225,3 +227,51 @@ func (client Client) UnlockAccount(ctx context.Context, account string, passphra
 	}
 	return res, nil
 }
+
+// FilterQuery eth_getLogs 的过滤条件，区块号为十六进制或者 "latest"
+// Topics 中每一项为该位置可以匹配的 topic 列表
+type FilterQuery struct {
+	FromBlock string     `json:"fromBlock,omitempty"`
+	ToBlock   string     `json:"toBlock,omitempty"`
+	Addresses []string   `json:"address,omitempty"`
+	Topics    [][]string `json:"topics,omitempty"`
+}
+
+// 获取当前的区块高度
+func (client Client) BlockNumber(ctx context.Context) (uint64, error) {
+	result, err := client.RpcClient.CallContext(ctx, types.GetblockNumber)
+	if err != nil {
+		return 0, err
+	}
+	var number hexutil.Uint64
+	if err = json.Unmarshal(result, &number); err != nil {
+		return 0, err
+	}
+	return uint64(number), nil
+}
+
+// 按照过滤条件查询日志
+func (client Client) GetLogs(ctx context.Context, query FilterQuery) ([]*packet.Log, error) {
+	result, err := client.RpcClient.CallContext(ctx, types.GetLogs, query)
+	if err != nil {
+		return nil, err
+	}
+	var logs []*packet.Log
+	if err = json.Unmarshal(result, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// 获取地址上最新区块的合约代码，没有合约时返回空
+func (client Client) GetCode(ctx context.Context, address string) ([]byte, error) {
+	result, err := client.RpcClient.CallContext(ctx, types.GetCode, address, "latest")
+	if err != nil {
+		return nil, err
+	}
+	var code hexutil.Bytes
+	if err = json.Unmarshal(result, &code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/Venachain/client-sdk-go/packet"
 	"github.com/Venachain/client-sdk-go/venachain/keystore"
@@ -70,11 +71,15 @@ func (cnsClient CnsClient) CnsExecute(ctx context.Context, funcName string, func
 // 通过合约名称以及版本号（默认为"latest"）解析出对应的账户地址。一个合约名可以对应多个（在注册的）合约地址，
 // 通过版本号解析出对应的合约地址，但在cns平台中已注销的合约名对应的版本号无法解析出相应的账户地址。
 func (cnsClient CnsClient) CnsResolve(ctx context.Context, version string) (string, error) {
+	return cnsClient.resolve(ctx, cnsClient.name, version)
+}
+
+func (cnsClient CnsClient) resolve(ctx context.Context, name string, version string) (string, error) {
 	funcName := "getContractAddress"
 	if version == "" {
 		version = "latest"
 	}
-	funcParams := []string{cnsClient.name, version}
+	funcParams := []string{name, version}
 
 	result, err := cnsClient.contractCallWithParams(ctx, funcParams, funcName, precompile.CnsManagementAddress)
 	if err != nil {
@@ -99,8 +104,13 @@ func (cnsClient CnsClient) CnsRedirect(ctx context.Context, version string) (str
 
 // 显示所有的cns 合约信息
 func (cnsClient CnsClient) CnsQueryAll(ctx context.Context) (string, error) {
+	return cnsClient.CnsQueryPage(ctx, 0, 0)
+}
+
+// 分页查询cns 合约信息，pageNum 从 0 开始，pageNum 和 pageSize 都为 0 时查询全部
+func (cnsClient CnsClient) CnsQueryPage(ctx context.Context, pageNum, pageSize int) (string, error) {
 	funcName := "getRegisteredContracts"
-	funcParams := []string{"0", "0"}
+	funcParams := []string{strconv.Itoa(pageNum), strconv.Itoa(pageSize)}
 
 	result, err := cnsClient.contractCallWithParams(ctx, funcParams, funcName, precompile.CnsManagementAddress)
 	if err != nil {
@@ -0,0 +1,353 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+)
+
+const (
+	// cns 合约注册和重定向时产生的事件
+	CnsNotifyEvent = "[CNS] Notify"
+	// 通过 cns 名称调用合约时产生的系统事件
+	CnsInvokeEventName = "CnsInvoke"
+)
+
+var ErrCnsNotRegistered = errors.New("the cns name is not registered")
+
+// 解析 cns 查询结果，data 可以是注册信息数组或者单条注册信息
+func parseCnsEntries(raw string) ([]syscontracts.CnsEntry, error) {
+	var entries []syscontracts.CnsEntry
+	err := ParseSysContractResult(raw, &entries)
+	if err == nil {
+		return entries, nil
+	}
+	var entry syscontracts.CnsEntry
+	if ParseSysContractResult(raw, &entry) == nil && entry.Name != "" {
+		return []syscontracts.CnsEntry{entry}, nil
+	}
+	return nil, err
+}
+
+// 查询所有的cns 合约注册信息
+func (cnsClient CnsClient) CnsEntries(ctx context.Context) ([]syscontracts.CnsEntry, error) {
+	return cnsClient.CnsEntriesPage(ctx, 0, 0)
+}
+
+// 分页查询cns 合约注册信息，pageNum 从 0 开始
+func (cnsClient CnsClient) CnsEntriesPage(ctx context.Context, pageNum, pageSize int) ([]syscontracts.CnsEntry, error) {
+	raw, err := cnsClient.CnsQueryPage(ctx, pageNum, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return parseCnsEntries(raw)
+}
+
+// 通过cns 客户端的名称查询合约注册信息
+func (cnsClient CnsClient) CnsEntriesByName(ctx context.Context) ([]syscontracts.CnsEntry, error) {
+	raw, err := cnsClient.CnsQueryByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parseCnsEntries(raw)
+}
+
+// 通过合约地址查询合约注册信息
+func (cnsClient CnsClient) CnsEntriesByAddress(ctx context.Context, contractAddress string) ([]syscontracts.CnsEntry, error) {
+	raw, err := cnsClient.CnsQueryByAddress(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return parseCnsEntries(raw)
+}
+
+// 通过注册者的账户地址查询合约注册信息
+func (cnsClient CnsClient) CnsEntriesByAccount(ctx context.Context, account string) ([]syscontracts.CnsEntry, error) {
+	raw, err := cnsClient.CnsQueryByAccount(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	return parseCnsEntries(raw)
+}
+
+// CnsIterator 按页遍历cns 合约注册信息
+//
+//	it := cnsClient.CnsIterator(100)
+//	for it.Next(ctx) {
+//		entry := it.Entry()
+//	}
+//	if it.Err() != nil {...}
+type CnsIterator struct {
+	client   CnsClient
+	pageSize int
+	pageNum  int
+
+	entries []syscontracts.CnsEntry
+	pos     int
+	last    bool
+	err     error
+}
+
+// 创建分页迭代器，pageSize 不大于 0 时使用 100
+func (cnsClient CnsClient) CnsIterator(pageSize int) *CnsIterator {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	return &CnsIterator{client: cnsClient, pageSize: pageSize, pos: -1}
+}
+
+// Next 移动到下一条注册信息，没有更多的数据或者出错时返回 false
+func (it *CnsIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos+1 < len(it.entries) {
+		it.pos++
+		return true
+	}
+	if it.last {
+		return false
+	}
+
+	entries, err := it.client.CnsEntriesPage(ctx, it.pageNum, it.pageSize)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.pageNum++
+	// 不足一页或者节点忽略了分页参数时，说明已经是最后一页
+	it.last = len(entries) == 0 || len(entries) != it.pageSize
+	it.entries = entries
+	it.pos = 0
+	return len(entries) > 0
+}
+
+// Entry 当前的注册信息
+func (it *CnsIterator) Entry() syscontracts.CnsEntry {
+	if it.pos < 0 || it.pos >= len(it.entries) {
+		return syscontracts.CnsEntry{}
+	}
+	return it.entries[it.pos]
+}
+
+func (it *CnsIterator) Err() error {
+	return it.err
+}
+
+// CnsResolver 缓存 name@version 到合约地址的解析结果
+// 缓存在 TTL 后过期，Sync 或 Watch 发现 cns 事件后自动失效对应的缓存
+type CnsResolver struct {
+	client CnsClient
+	ttl    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cnsCacheItem
+	// 分配给查询的序号，查询期间缓存项被失效或者重新查询时不写回查询结果
+	gen       uint64
+	lastBlock uint64
+	synced    bool
+
+	// 用于测试
+	now func() time.Time
+}
+
+// address 为空时表示正在查询
+type cnsCacheItem struct {
+	name    string
+	address string
+	expire  time.Time
+	gen     uint64
+}
+
+// 创建 cns 解析缓存，ttl 不大于 0 时缓存只会被事件失效
+func NewCnsResolver(cnsClient CnsClient, ttl time.Duration) *CnsResolver {
+	return &CnsResolver{
+		client: cnsClient,
+		ttl:    ttl,
+		cache:  make(map[string]cnsCacheItem),
+		now:    time.Now,
+	}
+}
+
+func cnsCacheKey(name, version string) string {
+	if version == "" {
+		version = "latest"
+	}
+	return name + "@" + version
+}
+
+// Resolve 解析合约名称和版本号（默认为"latest"）对应的合约地址，优先使用缓存
+func (r *CnsResolver) Resolve(ctx context.Context, name string, version string) (string, error) {
+	key := cnsCacheKey(name, version)
+	r.mu.Lock()
+	item, ok := r.cache[key]
+	if ok && item.address != "" && (r.ttl <= 0 || r.now().Before(item.expire)) {
+		r.mu.Unlock()
+		return item.address, nil
+	}
+	// 没有正在进行的查询时记录新的查询，同一个 key 的并发查询共用序号
+	if !ok || item.address != "" {
+		r.gen++
+		item = cnsCacheItem{name: name, gen: r.gen}
+		r.cache[key] = item
+	}
+	r.mu.Unlock()
+
+	address, err := r.client.resolve(ctx, name, version)
+	if err == nil {
+		address = strings.TrimSpace(address)
+		if address == "" || common.HexToAddress(address) == (common.Address{}) {
+			err = ErrCnsNotRegistered
+		}
+	}
+
+	r.mu.Lock()
+	if current, ok := r.cache[key]; ok && current.gen == item.gen {
+		if err == nil {
+			r.cache[key] = cnsCacheItem{name: name, address: address, expire: r.now().Add(r.ttl), gen: item.gen}
+		} else if current.address == "" {
+			delete(r.cache, key)
+		}
+	}
+	r.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+// cnsKeyName 缓存 key 中的合约名称，名称中不会包含 @
+func cnsKeyName(key string) string {
+	return key[:strings.LastIndex(key, "@")]
+}
+
+// invalidate 失效缓存 key，正在进行的查询不会再写回结果，调用时需要持有锁
+func (r *CnsResolver) invalidate(key string) {
+	delete(r.cache, key)
+}
+
+// Invalidate 失效合约名称所有版本的缓存
+func (r *CnsResolver) Invalidate(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.cache {
+		if cnsKeyName(key) == name {
+			r.invalidate(key)
+		}
+	}
+}
+
+// Purge 清空缓存
+func (r *CnsResolver) Purge() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.purge()
+}
+
+func (r *CnsResolver) purge() {
+	for key := range r.cache {
+		r.invalidate(key)
+	}
+}
+
+// HandleLog 处理 cns 事件日志，返回日志是否为 cns 事件
+// 事件信息中包含已缓存的合约名称时只失效该名称，否则清空缓存
+func (r *CnsResolver) HandleLog(eLog *packet.Log) bool {
+	if len(eLog.Topics) == 0 {
+		return false
+	}
+	topic := eLog.Topics[0]
+	if !strings.EqualFold(topic, packet.WasmEventTopic(CnsNotifyEvent)) &&
+		!strings.EqualFold(topic, packet.WasmEventTopic(CnsInvokeEventName)) {
+		return false
+	}
+
+	var msg string
+	if values, err := packet.WasmEventData(eLog, sysEventTypes); err == nil {
+		msg, _ = values[1].(string)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	matched := false
+	for key := range r.cache {
+		if msg != "" && strings.Contains(msg, cnsKeyName(key)) {
+			r.invalidate(key)
+			matched = true
+		}
+	}
+	if !matched {
+		r.purge()
+	}
+	return true
+}
+
+// Sync 查询上次同步之后的 cns 事件并失效对应的缓存
+// 第一次调用只记录当前的区块高度
+func (r *CnsResolver) Sync(ctx context.Context) error {
+	head, err := r.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	from, synced := r.lastBlock+1, r.synced
+	r.mu.Unlock()
+	if !synced {
+		r.setLastBlock(head)
+		return nil
+	}
+	if head < from {
+		return nil
+	}
+
+	logs, err := r.client.GetLogs(ctx, FilterQuery{
+		FromBlock: hexutil.EncodeUint64(from),
+		ToBlock:   hexutil.EncodeUint64(head),
+		Topics: [][]string{{
+			packet.WasmEventTopic(CnsNotifyEvent),
+			packet.WasmEventTopic(CnsInvokeEventName),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	for _, eLog := range logs {
+		r.HandleLog(eLog)
+	}
+	r.setLastBlock(head)
+	return nil
+}
+
+func (r *CnsResolver) setLastBlock(number uint64) {
+	r.mu.Lock()
+	r.lastBlock = number
+	r.synced = true
+	r.mu.Unlock()
+}
+
+// Watch 每隔 interval 同步一次 cns 事件，直到 ctx 结束
+// 同步出错时清空缓存，避免使用过期的地址
+func (r *CnsResolver) Watch(ctx context.Context, interval time.Duration) error {
+	if err := r.Sync(ctx); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := r.Sync(ctx); err != nil {
+				r.Purge()
+			}
+		}
+	}
+}
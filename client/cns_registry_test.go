@@ -0,0 +1,260 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+// wasm 合约返回的字符串
+func wasmStringResult(s string) string {
+	return hexutil.Encode(append(make([]byte, 64), []byte(s)...))
+}
+
+// 模拟 cns 合约，handler 按照合约方法名返回字符串结果
+func newMockCnsNode(t *testing.T, handler func(method string, params []interface{}) string, extra map[string]mockHandler) (*CnsClient, func()) {
+	contents := packet.PrecompiledContents(precompile.CnsManagementAddress)
+	handlers := map[string]mockHandler{
+		"eth_call": func(params []json.RawMessage) interface{} {
+			var tx struct {
+				To   common.Address `json:"to"`
+				Data hexutil.Bytes  `json:"data"`
+			}
+			if err := json.Unmarshal(params[0], &tx); err != nil {
+				t.Errorf("decode call error: %v", err)
+				return nil
+			}
+			call, err := packet.DecodeCallData(tx.Data, &tx.To, contents...)
+			if err != nil {
+				t.Errorf("decode call data error: %v", err)
+				return nil
+			}
+			values := make([]interface{}, 0, len(call.Params))
+			for _, p := range call.Params {
+				values = append(values, p.Value)
+			}
+			return wasmStringResult(handler(call.Method, values))
+		},
+	}
+	for method, h := range extra {
+		handlers[method] = h
+	}
+	server, url := newMockNode(t, handlers)
+	cnsClient, err := NewCnsClientWithKey(context.Background(), url, newTestKey(t), "wxbc")
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return cnsClient, func() {
+		cnsClient.RpcClient.Close()
+		server.Close()
+	}
+}
+
+func testCnsEntries(n int) []syscontracts.CnsEntry {
+	entries := make([]syscontracts.CnsEntry, 0, n)
+	for i := 0; i < n; i++ {
+		entries = append(entries, syscontracts.CnsEntry{
+			Name:       fmt.Sprintf("contract%d", i),
+			Version:    "1.0.0.0",
+			Address:    common.BigToAddress(common.Big1).Hex(),
+			Origin:     "0x1000000000000000000000000000000000000001",
+			CreateTime: uint64(1600000000 + i),
+			Enabled:    true,
+		})
+	}
+	return entries
+}
+
+func TestParseSysContractResult(t *testing.T) {
+	var entries []syscontracts.CnsEntry
+	raw := `{"code":0,"msg":"ok","data":[{"name":"a","version":"1.0.0.0","address":"0x01","origin":"0x02","create_time":1,"enabled":true}]}`
+	assert.NoError(t, ParseSysContractResult(raw, &entries))
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, "a", entries[0].Name)
+	assert.Equal(t, uint64(1), entries[0].CreateTime)
+	assert.True(t, entries[0].Enabled)
+
+	err := ParseSysContractResult(`{"code":1,"msg":"not found","data":""}`, &entries)
+	assert.EqualError(t, err, "system contract returned code 1: not found")
+
+	// 不带 code 的结果和字符串形式的 data
+	entries = nil
+	assert.NoError(t, ParseSysContractResult(`[{"name":"b"}]`, &entries))
+	assert.Equal(t, "b", entries[0].Name)
+	entries = nil
+	assert.NoError(t, ParseSysContractResult(`{"code":0,"msg":"ok","data":"[{\"name\":\"c\"}]"}`, &entries))
+	assert.Equal(t, "c", entries[0].Name)
+}
+
+func TestCnsClient_CnsIterator(t *testing.T) {
+	all := testCnsEntries(5)
+	var pages [][2]int
+	cnsClient, closeFn := newMockCnsNode(t, func(method string, params []interface{}) string {
+		assert.Equal(t, "getRegisteredContracts", method)
+		pageNum, _ := strconv.Atoi(fmt.Sprint(params[0]))
+		pageSize, _ := strconv.Atoi(fmt.Sprint(params[1]))
+		pages = append(pages, [2]int{pageNum, pageSize})
+		start, end := pageNum*pageSize, (pageNum+1)*pageSize
+		if pageSize == 0 {
+			start, end = 0, len(all)
+		}
+		if start > len(all) {
+			start = len(all)
+		}
+		if end > len(all) {
+			end = len(all)
+		}
+		data, _ := json.Marshal(map[string]interface{}{"code": 0, "msg": "ok", "data": all[start:end]})
+		return string(data)
+	}, nil)
+	defer closeFn()
+
+	ctx := context.Background()
+	var got []syscontracts.CnsEntry
+	it := cnsClient.CnsIterator(2)
+	for it.Next(ctx) {
+		got = append(got, it.Entry())
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, all, got)
+	assert.Equal(t, [][2]int{{0, 2}, {1, 2}, {2, 2}}, pages)
+
+	entries, err := cnsClient.CnsEntries(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, all, entries)
+	assert.Equal(t, [2]int{0, 0}, pages[len(pages)-1])
+}
+
+func cnsNotifyLog(code uint64, msg string) map[string]interface{} {
+	data, _ := rlp.EncodeToBytes([]interface{}{code, msg})
+	return map[string]interface{}{
+		"address": precompile.CnsManagementAddress,
+		"topics":  []string{packet.WasmEventTopic(CnsNotifyEvent)},
+		"data":    hexutil.Encode(data),
+	}
+}
+
+func TestCnsResolver(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		resolves int
+		block    uint64 = 10
+		logs     []interface{}
+	)
+	cnsClient, closeFn := newMockCnsNode(t, func(method string, params []interface{}) string {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "getContractAddress", method)
+		resolves++
+		if params[0] == "unknown" {
+			return "0x0000000000000000000000000000000000000000"
+		}
+		return common.BigToAddress(common.Big1).Hex()
+	}, map[string]mockHandler{
+		"eth_blockNumber": func(params []json.RawMessage) interface{} {
+			mu.Lock()
+			defer mu.Unlock()
+			return hexutil.EncodeUint64(block)
+		},
+		"eth_getLogs": func(params []json.RawMessage) interface{} {
+			mu.Lock()
+			defer mu.Unlock()
+			var query FilterQuery
+			_ = json.Unmarshal(params[0], &query)
+			assert.Equal(t, hexutil.EncodeUint64(11), query.FromBlock)
+			assert.Equal(t, [][]string{{packet.WasmEventTopic(CnsNotifyEvent), packet.WasmEventTopic(CnsInvokeEventName)}}, query.Topics)
+			return logs
+		},
+	})
+	defer closeFn()
+
+	ctx := context.Background()
+	now := time.Now()
+	resolver := NewCnsResolver(*cnsClient, time.Minute)
+	resolver.now = func() time.Time { return now }
+	assert.NoError(t, resolver.Sync(ctx))
+
+	for i := 0; i < 3; i++ {
+		address, err := resolver.Resolve(ctx, "wxbc", "")
+		assert.NoError(t, err)
+		assert.Equal(t, common.BigToAddress(common.Big1).Hex(), address)
+	}
+	_, err := resolver.Resolve(ctx, "other", "1.0.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resolves)
+
+	_, err = resolver.Resolve(ctx, "unknown", "")
+	assert.Equal(t, ErrCnsNotRegistered, err)
+	// 解析失败的名称不会留在缓存中
+	assert.Len(t, resolver.cache, 2)
+
+	// 过期后重新解析
+	now = now.Add(2 * time.Minute)
+	_, _ = resolver.Resolve(ctx, "wxbc", "latest")
+	_, _ = resolver.Resolve(ctx, "other", "1.0.0.0")
+	assert.Equal(t, 5, resolves)
+
+	// 事件只失效对应的名称
+	mu.Lock()
+	block = 12
+	logs = []interface{}{cnsNotifyLog(0, "[CNS] cns register successfully: wxbc")}
+	mu.Unlock()
+	assert.NoError(t, resolver.Sync(ctx))
+	_, _ = resolver.Resolve(ctx, "wxbc", "")
+	_, _ = resolver.Resolve(ctx, "other", "1.0.0.0")
+	assert.Equal(t, 6, resolves)
+
+	// 无法确定名称的事件清空缓存
+	assert.True(t, resolver.HandleLog(&packet.Log{
+		Topics: []string{packet.WasmEventTopic(CnsInvokeEventName)},
+		Data:   "0x",
+	}))
+	_, _ = resolver.Resolve(ctx, "other", "1.0.0.0")
+	assert.Equal(t, 7, resolves)
+	assert.Len(t, resolver.cache, 1)
+
+	assert.False(t, resolver.HandleLog(&packet.Log{Topics: []string{packet.WasmEventTopic("other")}}))
+}
+
+func TestCnsResolver_InvalidateDuringResolve(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		resolves int
+		resolver *CnsResolver
+	)
+	cnsClient, closeFn := newMockCnsNode(t, func(method string, params []interface{}) string {
+		mu.Lock()
+		resolves++
+		first := resolves == 1
+		mu.Unlock()
+		// 第一次查询返回前合约被重定向
+		if first {
+			resolver.Invalidate("wxbc")
+		}
+		return common.BigToAddress(common.Big1).Hex()
+	}, nil)
+	defer closeFn()
+
+	ctx := context.Background()
+	resolver = NewCnsResolver(*cnsClient, time.Minute)
+	for i := 0; i < 3; i++ {
+		_, err := resolver.Resolve(ctx, "wxbc", "")
+		assert.NoError(t, err)
+	}
+	// 查询期间被失效的结果不会被缓存
+	assert.Equal(t, 2, resolves)
+	assert.Len(t, resolver.cache, 1)
+}
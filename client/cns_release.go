@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+)
+
+// VersionBump 新版本号相对于已注册的最高版本号的升级方式
+// cns 的版本号为 major.minor.patch.build 四段
+type VersionBump int
+
+const (
+	BumpPatch VersionBump = iota
+	BumpMinor
+	BumpMajor
+	BumpBuild
+)
+
+const defaultInitialVersion = "1.0.0.0"
+
+// VersionPolicy 发布合约时选择版本号的策略
+type VersionPolicy struct {
+	Bump VersionBump
+	// 指定版本号，不为空时忽略 Bump，必须高于已注册的版本号
+	Version string
+	// 合约名称第一次注册时使用的版本号，默认为 1.0.0.0
+	Initial string
+	// 注册后将 latest 重定向到新版本并校验，失败时重定向回发布前 latest 指向的版本
+	Redirect bool
+}
+
+// CnsRelease 合约发布记录
+type CnsRelease struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	PreviousVersion string `json:"previousVersion,omitempty"`
+	// 发布前 latest 指向的版本，不一定是已注册的最高版本
+	LatestVersion  string `json:"latestVersion,omitempty"`
+	Address        string `json:"address"`
+	DeployTxHash   string `json:"deployTxHash"`
+	RegisterTxHash string `json:"registerTxHash,omitempty"`
+	RedirectTxHash string `json:"redirectTxHash,omitempty"`
+	BlockNumber    uint64 `json:"blockNumber"`
+	Redirected     bool   `json:"redirected"`
+	RolledBack     bool   `json:"rolledBack,omitempty"`
+}
+
+// cnsVersion 四段式的 cns 版本号
+type cnsVersion [4]uint64
+
+func parseCnsVersion(version string) (cnsVersion, error) {
+	var v cnsVersion
+	if !packet.IsMatch(version, "version") || version == "latest" {
+		return v, fmt.Errorf("invalid cns version %s, should be like 1.0.0.0", version)
+	}
+	for i, part := range strings.Split(version, ".") {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid cns version %s: %v", version, err)
+		}
+		v[i] = n
+	}
+	return v, nil
+}
+
+func (v cnsVersion) compare(other cnsVersion) int {
+	for i := range v {
+		if v[i] != other[i] {
+			if v[i] < other[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func (v cnsVersion) bump(bump VersionBump) cnsVersion {
+	switch bump {
+	case BumpMajor:
+		return cnsVersion{v[0] + 1, 0, 0, 0}
+	case BumpMinor:
+		return cnsVersion{v[0], v[1] + 1, 0, 0}
+	case BumpBuild:
+		return cnsVersion{v[0], v[1], v[2], v[3] + 1}
+	default:
+		return cnsVersion{v[0], v[1], v[2] + 1, 0}
+	}
+}
+
+func (v cnsVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", v[0], v[1], v[2], v[3])
+}
+
+// NextVersion 根据已注册的版本号和策略计算新版本号，previous 为已注册的最高版本号，没有注册过时为空
+func (policy VersionPolicy) NextVersion(registered []string) (next string, previous string, err error) {
+	var (
+		highest cnsVersion
+		found   bool
+	)
+	for _, version := range registered {
+		v, err := parseCnsVersion(version)
+		if err != nil {
+			continue
+		}
+		if !found || v.compare(highest) > 0 {
+			highest, found = v, true
+		}
+	}
+	if found {
+		previous = highest.String()
+	}
+
+	switch {
+	case policy.Version != "":
+		v, err := parseCnsVersion(policy.Version)
+		if err != nil {
+			return "", "", err
+		}
+		if found && v.compare(highest) <= 0 {
+			return "", "", fmt.Errorf("the version %s should be higher than the registered version %s", policy.Version, previous)
+		}
+		return v.String(), previous, nil
+	case !found:
+		initial := policy.Initial
+		if initial == "" {
+			initial = defaultInitialVersion
+		}
+		v, err := parseCnsVersion(initial)
+		if err != nil {
+			return "", "", err
+		}
+		return v.String(), "", nil
+	default:
+		return highest.bump(policy.Bump).String(), previous, nil
+	}
+}
+
+// DeployAndRegister 部署合约并注册到 cns，版本号由 policy 决定
+// 部署的虚拟机类型根据合约代码自动判断，args 为构造函数的参数
+// 部署成功但后续步骤失败时，同时返回已完成部分的发布记录和错误
+func (cnsClient CnsClient) DeployAndRegister(ctx context.Context, name, abiPath, codePath string, args []string, policy VersionPolicy) (*CnsRelease, error) {
+	if !packet.IsMatch(name, "name") || name == "" {
+		return nil, fmt.Errorf("invalid cns name %s", name)
+	}
+	named := cnsClient
+	named.name = name
+	entries, err := named.CnsEntriesByName(ctx)
+	if err != nil {
+		return nil, err
+	}
+	registered := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name == name {
+			registered = append(registered, entry.Version)
+		}
+	}
+	version, previous, err := policy.NextVersion(registered)
+	if err != nil {
+		return nil, err
+	}
+
+	release := &CnsRelease{Name: name, Version: version, PreviousVersion: previous}
+	if policy.Redirect && len(registered) > 0 {
+		// 注册新版本可能改变 latest，需要在注册前记录当前 latest 指向的版本
+		latest, err := cnsClient.resolve(ctx, name, "latest")
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.Name == name && common.HexToAddress(entry.Address) == common.HexToAddress(latest) {
+				release.LatestVersion = entry.Version
+			}
+		}
+	}
+	// 非 wasm 代码使用 evm 部署，wasm 代码会被自动识别
+	deployer := cnsClient.ContractClient
+	deployer.VmType = "evm"
+	dataGenerator, err := deployer.MakeDeployGenerator(abiPath, codePath, args)
+	if err != nil {
+		return nil, err
+	}
+	txparam, err := MakeTxparamForDeploy(dataGenerator, &cnsClient.Key.Address)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := cnsClient.sendAndWaitReceipt(ctx, txparam)
+	if err != nil {
+		return nil, fmt.Errorf("deploy contract error: %v", err)
+	}
+	release.DeployTxHash = receipt.TransactionHash
+	release.Address = receipt.ContractAddress
+	if !packet.IsMatch(release.Address, "address") {
+		return release, errors.New("the deployment receipt has no contract address")
+	}
+
+	receipt, err = cnsClient.cnsTransact(ctx, "cnsRegister", name, version, release.Address)
+	if receipt != nil {
+		release.RegisterTxHash = receipt.TransactionHash
+		release.BlockNumber = receipt.Parsing().BlockNumber
+	}
+	if err != nil {
+		return release, fmt.Errorf("register %s@%s error: %v", name, version, err)
+	}
+
+	if policy.Redirect {
+		if err = cnsClient.redirectLatest(ctx, release); err != nil {
+			return release, err
+		}
+	}
+	return release, nil
+}
+
+// redirectLatest 将 latest 重定向到新版本并校验，失败时重定向回发布前 latest 指向的版本
+func (cnsClient CnsClient) redirectLatest(ctx context.Context, release *CnsRelease) error {
+	receipt, err := cnsClient.cnsTransact(ctx, "cnsRedirect", release.Name, release.Version)
+	if receipt != nil {
+		release.RedirectTxHash = receipt.TransactionHash
+	}
+	if err == nil {
+		var address string
+		address, err = cnsClient.resolve(ctx, release.Name, "latest")
+		if err == nil && common.HexToAddress(address) != common.HexToAddress(release.Address) {
+			err = fmt.Errorf("latest is resolved to %s, expect %s", address, release.Address)
+		}
+	}
+	if err == nil {
+		release.Redirected = true
+		return nil
+	}
+
+	err = fmt.Errorf("redirect %s to %s error: %v", release.Name, release.Version, err)
+	if release.LatestVersion == "" {
+		return err
+	}
+	if _, rollbackErr := cnsClient.cnsTransact(ctx, "cnsRedirect", release.Name, release.LatestVersion); rollbackErr != nil {
+		return fmt.Errorf("%v, and roll back to %s error: %v", err, release.LatestVersion, rollbackErr)
+	}
+	release.RolledBack = true
+	return err
+}
+
+// cnsTransact 调用 cns 合约的写方法，并检查回执中 cns 事件的返回码
+func (cnsClient CnsClient) cnsTransact(ctx context.Context, funcName string, funcParams ...string) (*packet.Receipt, error) {
+	return cnsClient.sysContractTransact(ctx, precompile.CnsManagementAddress, funcName, funcParams...)
+}
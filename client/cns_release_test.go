@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/types"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionPolicy_NextVersion(t *testing.T) {
+	registered := []string{"1.0.0.0", "1.2.3.4", "1.10.0.0", "bad"}
+	for _, c := range []struct {
+		policy   VersionPolicy
+		expected string
+	}{
+		{VersionPolicy{Bump: BumpPatch}, "1.10.1.0"},
+		{VersionPolicy{Bump: BumpMinor}, "1.11.0.0"},
+		{VersionPolicy{Bump: BumpMajor}, "2.0.0.0"},
+		{VersionPolicy{Bump: BumpBuild}, "1.10.0.1"},
+		{VersionPolicy{Version: "3.0.0.0"}, "3.0.0.0"},
+	} {
+		next, previous, err := c.policy.NextVersion(registered)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, next)
+		assert.Equal(t, "1.10.0.0", previous)
+	}
+
+	_, _, err := VersionPolicy{Version: "1.2.0.0"}.NextVersion(registered)
+	assert.Error(t, err)
+
+	next, previous, err := VersionPolicy{}.NextVersion(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultInitialVersion, next)
+	assert.Equal(t, "", previous)
+}
+
+// 模拟 cns 合约的注册和重定向
+type mockCnsRegistry struct {
+	mu       sync.Mutex
+	entries  []syscontracts.CnsEntry
+	latest   string
+	receipts map[string]map[string]interface{}
+	// 重定向到该版本时 latest 解析为空地址，用于测试回滚
+	brokenRedirect string
+	redirects      []string
+	deployed       int64
+}
+
+func (m *mockCnsRegistry) handlers(t *testing.T) map[string]mockHandler {
+	contents := packet.PrecompiledContents(precompile.CnsManagementAddress)
+	return map[string]mockHandler{
+		"eth_call": func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			var tx struct {
+				To   common.Address `json:"to"`
+				Data hexutil.Bytes  `json:"data"`
+			}
+			_ = json.Unmarshal(params[0], &tx)
+			call, err := packet.DecodeCallData(tx.Data, &tx.To, contents...)
+			if err != nil {
+				t.Errorf("decode call data error: %v", err)
+				return nil
+			}
+			switch call.Method {
+			case "getRegisteredContractsByName":
+				data, _ := json.Marshal(map[string]interface{}{"code": 0, "msg": "ok", "data": m.entries})
+				return wasmStringResult(string(data))
+			case "getContractAddress":
+				return wasmStringResult(m.latest)
+			}
+			t.Errorf("unexpected call %s", call.Method)
+			return nil
+		},
+		"eth_sendRawTransaction": func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			raw, _ := hexutil.Decode(mockParamString(params, 0))
+			tx := new(types.Transaction)
+			_ = rlp.DecodeBytes(raw, tx)
+			hash := tx.Hash().Hex()
+			receipt := map[string]interface{}{
+				"transactionHash": hash,
+				"blockNumber":     "0x10",
+				"status":          "0x1",
+				"logs":            []interface{}{},
+			}
+			m.receipts[hash] = receipt
+			if tx.To() == nil {
+				m.deployed++
+				receipt["contractAddress"] = common.BigToAddress(big.NewInt(m.deployed)).Hex()
+				return hash
+			}
+
+			call, err := packet.DecodeCallData(tx.Data(), tx.To(), contents...)
+			if err != nil {
+				t.Errorf("decode call data error: %v", err)
+				return hash
+			}
+			switch call.Method {
+			case "cnsRegister":
+				m.entries = append(m.entries, syscontracts.CnsEntry{
+					Name:    call.Params[0].Value.(string),
+					Version: call.Params[1].Value.(string),
+					Address: call.Params[2].Value.(string),
+					Enabled: true,
+				})
+				m.latest = call.Params[2].Value.(string)
+				receipt["logs"] = []interface{}{cnsNotifyLog(0, "[CNS] cns register successfully")}
+			case "cnsRedirect":
+				version := call.Params[1].Value.(string)
+				m.redirects = append(m.redirects, version)
+				if version == m.brokenRedirect {
+					m.latest = common.Address{}.Hex()
+					break
+				}
+				for _, entry := range m.entries {
+					if entry.Version == version {
+						m.latest = entry.Address
+					}
+				}
+				receipt["logs"] = []interface{}{cnsNotifyLog(0, "[CNS] cns redirect successfully")}
+			}
+			return hash
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			return m.receipts[mockParamString(params, 0)]
+		},
+	}
+}
+
+func writeTestContract(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	abiPath := filepath.Join(dir, "test.abi.json")
+	codePath := filepath.Join(dir, "test.wasm")
+	assert.NoError(t, ioutil.WriteFile(abiPath, []byte("[]"), 0644))
+	assert.NoError(t, ioutil.WriteFile(codePath, []byte{0, 97, 115, 109, 1, 0, 0, 0, 1, 2, 3}, 0644))
+	return abiPath, codePath
+}
+
+func TestCnsClient_DeployAndRegister(t *testing.T) {
+	previous := common.BigToAddress(common.Big2).Hex()
+	registry := &mockCnsRegistry{
+		entries:  []syscontracts.CnsEntry{{Name: "wxbc", Version: "1.0.1.0", Address: previous, Enabled: true}},
+		latest:   previous,
+		receipts: make(map[string]map[string]interface{}),
+		deployed: 2,
+	}
+	server, url := newMockNode(t, registry.handlers(t))
+	defer server.Close()
+	cnsClient, err := NewCnsClientWithKey(context.Background(), url, newTestKey(t), "")
+	assert.NoError(t, err)
+	defer cnsClient.RpcClient.Close()
+	abiPath, codePath := writeTestContract(t)
+
+	release, err := cnsClient.DeployAndRegister(context.Background(), "wxbc", abiPath, codePath, nil, VersionPolicy{Bump: BumpMinor, Redirect: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0.0", release.Version)
+	assert.Equal(t, "1.0.1.0", release.PreviousVersion)
+	assert.Equal(t, "1.0.1.0", release.LatestVersion)
+	assert.Equal(t, common.BigToAddress(common.Big3).Hex(), release.Address)
+	assert.Equal(t, uint64(16), release.BlockNumber)
+	assert.True(t, release.Redirected)
+	assert.NotEmpty(t, release.DeployTxHash)
+	assert.NotEmpty(t, release.RegisterTxHash)
+	assert.NotEmpty(t, release.RedirectTxHash)
+	assert.Equal(t, []string{"1.1.0.0"}, registry.redirects)
+
+	// 重定向校验失败时回滚到发布前 latest 指向的版本，而不是已注册的最高版本
+	registry.latest = previous
+	registry.brokenRedirect = "1.2.0.0"
+	release, err = cnsClient.DeployAndRegister(context.Background(), "wxbc", abiPath, codePath, nil, VersionPolicy{Bump: BumpMinor, Redirect: true})
+	assert.Error(t, err)
+	assert.Equal(t, "1.2.0.0", release.Version)
+	assert.False(t, release.Redirected)
+	assert.True(t, release.RolledBack)
+	assert.Equal(t, "1.1.0.0", release.PreviousVersion)
+	assert.Equal(t, "1.0.1.0", release.LatestVersion)
+	assert.Equal(t, []string{"1.1.0.0", "1.2.0.0", "1.0.1.0"}, registry.redirects)
+	assert.Equal(t, previous, registry.latest)
+}
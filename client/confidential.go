@@ -0,0 +1,451 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/venachain/bn256"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto/bp"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+// 转账证明和销毁证明中的金额和余额使用 16 位的范围证明
+const MaxConfidentialAmount = 1<<16 - 1
+
+// 匿名集合的大小必须是 2 的幂，受范围证明生成元数量的限制最大为 128
+const (
+	MinAnonymitySetSize = 2
+	MaxAnonymitySetSize = 128
+)
+
+var (
+	ErrInvalidConfidentialProof = errors.New("the confidential proof is invalid")
+	ErrBalanceMismatch          = errors.New("the encrypted balance does not match the plaintext balance")
+)
+
+// ConfidentialKey 匿名账户在 bn256 上的密钥对
+type ConfidentialKey struct {
+	Private *big.Int
+	Public  *bn256.G1
+}
+
+// GenerateConfidentialKey 生成匿名账户的密钥
+func GenerateConfidentialKey() (*ConfidentialKey, error) {
+	pair, err := bp.NewKeyPair(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := pair.GetPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return &ConfidentialKey{Private: pair.GetPrivateKey(), Public: pub}, nil
+}
+
+// NewConfidentialKey 使用已有的私钥构造匿名账户的密钥
+func NewConfidentialKey(priv *big.Int) *ConfidentialKey {
+	return &ConfidentialKey{Private: priv, Public: new(bn256.G1).ScalarMult(bp.G, priv)}
+}
+
+// PublicKeyHex 十六进制编码的公钥
+func (key *ConfidentialKey) PublicKeyHex() string {
+	return hexutil.Encode(key.Public.Marshal())
+}
+
+// ParseConfidentialPublicKey 解析十六进制编码的公钥
+func ParseConfidentialPublicKey(s string) (*bn256.G1, error) {
+	bytes, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	pub := new(bn256.G1)
+	if _, err := pub.Unmarshal(bytes); err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	return pub, nil
+}
+
+// Nonce 账户在 epoch 中的 nonce，用于防止同一个 epoch 内重复花费
+func (key *ConfidentialKey) Nonce(epoch *big.Int) *bn256.G1 {
+	return new(bn256.G1).ScalarMult(epochGenerator(epoch), key.Private)
+}
+
+func epochGenerator(epoch *big.Int) *bn256.G1 {
+	return bp.MapIntoGroup("zether" + epoch.String())
+}
+
+// Encrypt 使用账户的公钥加密金额
+func (key *ConfidentialKey) Encrypt(amount uint64) (*bp.Ciphertext, error) {
+	return bp.Enc(rand.Reader, key.Public, new(big.Int).SetUint64(amount))
+}
+
+// DecryptBalance 解密账户的余额，余额不超过 max，max 最大为 MaxConfidentialAmount
+func (key *ConfidentialKey) DecryptBalance(balance *bp.Ciphertext, max uint64) (uint64, error) {
+	if max > MaxConfidentialAmount {
+		max = MaxConfidentialAmount
+	}
+	m := bp.Dec(balance, key.Private).Marshal()
+	p := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	g := new(bn256.G1).ScalarMult(bp.G, big.NewInt(1))
+	for i := uint64(0); i <= max; i++ {
+		if string(p.Marshal()) == string(m) {
+			return i, nil
+		}
+		p = new(bn256.G1).Add(p, g)
+	}
+	return 0, fmt.Errorf("the balance is greater than %d", max)
+}
+
+// 检查密文是否是 value 的加密结果
+func (key *ConfidentialKey) checkBalance(balance *bp.Ciphertext, value uint64) error {
+	expect := new(bn256.G1).ScalarMult(bp.G, new(big.Int).SetUint64(value))
+	if string(bp.Dec(balance, key.Private).Marshal()) != string(expect.Marshal()) {
+		return ErrBalanceMismatch
+	}
+	return nil
+}
+
+// ConfidentialAccount 匿名集合中的账户及其当前的加密余额
+type ConfidentialAccount struct {
+	PublicKey *bn256.G1
+	Balance   *bp.Ciphertext
+}
+
+// ConfidentialTransferRequest 匿名转账请求
+// Decoys 为混淆用的其他账户，Decoys 的个数加 2 必须是 2 的幂
+type ConfidentialTransferRequest struct {
+	Sender   *ConfidentialKey
+	Balance  *bp.Ciphertext
+	Value    uint64
+	Receiver ConfidentialAccount
+	Decoys   []ConfidentialAccount
+	Amount   uint64
+	Epoch    *big.Int
+}
+
+// ConfidentialTransfer 匿名转账的声明和证明
+type ConfidentialTransfer struct {
+	Statement *bp.TransferStatement
+	Proof     *bp.TransferProof
+}
+
+// BuildConfidentialTransfer 生成匿名转账的证明
+// 发送方和接收方放在匿名集合中奇偶性不同的随机位置，所有账户的余额都加上各自的转账密文
+func BuildConfidentialTransfer(req ConfidentialTransferRequest) (*ConfidentialTransfer, error) {
+	n := len(req.Decoys) + 2
+	if n < MinAnonymitySetSize || n > MaxAnonymitySetSize || n&(n-1) != 0 {
+		return nil, fmt.Errorf("invalid anonymity set size %d", n)
+	}
+	if req.Amount > req.Value || req.Value > MaxConfidentialAmount {
+		return nil, fmt.Errorf("invalid transfer amount %d with balance %d", req.Amount, req.Value)
+	}
+	if req.Epoch == nil {
+		return nil, errors.New("the epoch is required")
+	}
+	if req.Sender == nil || req.Sender.Private == nil || req.Balance == nil {
+		return nil, errors.New("the sender key and balance are required")
+	}
+	for _, account := range append([]ConfidentialAccount{req.Receiver}, req.Decoys...) {
+		if account.PublicKey == nil || account.Balance == nil {
+			return nil, errors.New("the public key and balance of the receiver and decoys are required")
+		}
+	}
+	if err := req.Sender.checkBalance(req.Balance, req.Value); err != nil {
+		return nil, err
+	}
+
+	// 发送方和接收方在匿名集合中的位置必须不可预测
+	l0, err := randIndex(n)
+	if err != nil {
+		return nil, err
+	}
+	half, err := randIndex(n / 2)
+	if err != nil {
+		return nil, err
+	}
+	l1 := half*2 + (l0+1)%2
+	accounts := make([]ConfidentialAccount, 0, n)
+	decoys := req.Decoys
+	for i := 0; i < n; i++ {
+		switch i {
+		case l0:
+			accounts = append(accounts, ConfidentialAccount{PublicKey: req.Sender.Public, Balance: req.Balance})
+		case l1:
+			accounts = append(accounts, req.Receiver)
+		default:
+			accounts = append(accounts, decoys[0])
+			decoys = decoys[1:]
+		}
+	}
+
+	r, err := rand.Int(rand.Reader, bp.ORDER)
+	if err != nil {
+		return nil, err
+	}
+	amount := new(big.Int).SetUint64(req.Amount)
+	statement := &bp.TransferStatement{
+		AnonPk:  make([]*bn256.G1, n),
+		CLnNew:  make([]*bn256.G1, n),
+		CRnNew:  make([]*bn256.G1, n),
+		CVector: make([]*bn256.G1, n),
+		D:       new(bn256.G1).ScalarMult(bp.G, r),
+		NonceU:  req.Sender.Nonce(req.Epoch),
+		Epoch:   req.Epoch,
+	}
+	for i, account := range accounts {
+		if account.PublicKey == nil || account.Balance == nil {
+			return nil, fmt.Errorf("the account %d of the anonymity set is incomplete", i)
+		}
+		c := new(bn256.G1).ScalarMult(account.PublicKey, r)
+		switch i {
+		case l0:
+			c = new(bn256.G1).Add(new(bn256.G1).ScalarMult(bp.G, new(big.Int).Neg(amount)), c)
+		case l1:
+			c = new(bn256.G1).Add(new(bn256.G1).ScalarMult(bp.G, amount), c)
+		}
+		statement.AnonPk[i] = account.PublicKey
+		statement.CVector[i] = c
+		statement.CLnNew[i] = new(bn256.G1).Add(account.Balance.C, c)
+		statement.CRnNew[i] = new(bn256.G1).Add(account.Balance.D, statement.D)
+	}
+
+	proof, err := bp.TfProver(statement.AnonPk, statement.CLnNew, statement.CRnNew, statement.CVector, statement.D, statement.NonceU, statement.Epoch,
+		req.Sender.Private, amount, new(big.Int).SetUint64(req.Value-req.Amount), r, big.NewInt(int64(l0)), big.NewInt(int64(l1)))
+	if err != nil {
+		return nil, err
+	}
+	return &ConfidentialTransfer{Statement: statement, Proof: proof}, nil
+}
+
+// Verify 在本地验证匿名转账的证明
+func (transfer *ConfidentialTransfer) Verify() error {
+	ok, err := bp.TransferVerify(transfer.Statement, transfer.Proof)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfidentialProof, err)
+	}
+	if !ok {
+		return ErrInvalidConfidentialProof
+	}
+	return nil
+}
+
+// 提交到合约的匿名转账数据
+type confidentialTransferData struct {
+	AnonPk  []string `json:"anonPk"`
+	CLnNew  []string `json:"cLnNew"`
+	CRnNew  []string `json:"cRnNew"`
+	CVector []string `json:"cVector"`
+	D       string   `json:"d"`
+	NonceU  string   `json:"nonceU"`
+	Epoch   string   `json:"epoch"`
+	Proof   string   `json:"proof"`
+}
+
+func encodePoints(points []*bn256.G1) []string {
+	result := make([]string, 0, len(points))
+	for _, p := range points {
+		result = append(result, hexutil.Encode(p.Marshal()))
+	}
+	return result
+}
+
+func decodePoints(points []string) ([]*bn256.G1, error) {
+	result := make([]*bn256.G1, 0, len(points))
+	for _, s := range points {
+		p, err := ParseConfidentialPublicKey(s)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// Marshal 将匿名转账编码为 json
+func (transfer *ConfidentialTransfer) Marshal() string {
+	s := transfer.Statement
+	bytes, _ := json.Marshal(confidentialTransferData{
+		AnonPk:  encodePoints(s.AnonPk),
+		CLnNew:  encodePoints(s.CLnNew),
+		CRnNew:  encodePoints(s.CRnNew),
+		CVector: encodePoints(s.CVector),
+		D:       hexutil.Encode(s.D.Marshal()),
+		NonceU:  hexutil.Encode(s.NonceU.Marshal()),
+		Epoch:   s.Epoch.String(),
+		Proof:   bp.TfProofMarshal(transfer.Proof),
+	})
+	return string(bytes)
+}
+
+// UnmarshalConfidentialTransfer 解析 json 编码的匿名转账
+func UnmarshalConfidentialTransfer(data string) (*ConfidentialTransfer, error) {
+	var raw confidentialTransferData
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, err
+	}
+	s := new(bp.TransferStatement)
+	var err error
+	for _, field := range []struct {
+		dst *[]*bn256.G1
+		src []string
+	}{{&s.AnonPk, raw.AnonPk}, {&s.CLnNew, raw.CLnNew}, {&s.CRnNew, raw.CRnNew}, {&s.CVector, raw.CVector}} {
+		if *field.dst, err = decodePoints(field.src); err != nil {
+			return nil, err
+		}
+	}
+	if s.D, err = ParseConfidentialPublicKey(raw.D); err != nil {
+		return nil, err
+	}
+	if s.NonceU, err = ParseConfidentialPublicKey(raw.NonceU); err != nil {
+		return nil, err
+	}
+	var ok bool
+	if s.Epoch, ok = new(big.Int).SetString(raw.Epoch, 10); !ok {
+		return nil, fmt.Errorf("invalid epoch %s", raw.Epoch)
+	}
+	proof, err := bp.TfProofUnMarshal(raw.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfidentialTransfer{Statement: s, Proof: proof}, nil
+}
+
+// ConfidentialWithdraw 销毁（提取）匿名余额的声明和证明
+type ConfidentialWithdraw struct {
+	CLn       *bn256.G1
+	CRn       *bn256.G1
+	PublicKey *bn256.G1
+	NonceU    *bn256.G1
+	Epoch     *big.Int
+	Sender    common.Address
+	Amount    uint64
+	Proof     *bp.WithdrawProof
+}
+
+// BuildConfidentialWithdraw 生成从加密余额中提取 amount 的证明，sender 为发送交易的账户地址
+func BuildConfidentialWithdraw(key *ConfidentialKey, balance *bp.Ciphertext, value uint64, amount uint64, epoch *big.Int, sender common.Address) (*ConfidentialWithdraw, error) {
+	if amount > value || value > MaxConfidentialAmount {
+		return nil, fmt.Errorf("invalid withdraw amount %d with balance %d", amount, value)
+	}
+	if epoch == nil {
+		return nil, errors.New("the epoch is required")
+	}
+	if key == nil || key.Private == nil || balance == nil {
+		return nil, errors.New("the key and balance are required")
+	}
+	if err := key.checkBalance(balance, value); err != nil {
+		return nil, err
+	}
+	cLn := new(bn256.G1).Add(balance.C, new(bn256.G1).ScalarMult(bp.G, new(big.Int).Neg(new(big.Int).SetUint64(amount))))
+	witness := bp.NewWithdrawWit(key.Private, bp.NewAggBpWitness([]*big.Int{new(big.Int).SetUint64(value - amount)}))
+	proof, err := bp.WithdrawProve(cLn, balance.D, key.Public, epoch, sender.Bytes(), witness)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfidentialWithdraw{
+		CLn:       cLn,
+		CRn:       balance.D,
+		PublicKey: key.Public,
+		NonceU:    key.Nonce(epoch),
+		Epoch:     epoch,
+		Sender:    sender,
+		Amount:    amount,
+		Proof:     proof,
+	}, nil
+}
+
+// Verify 在本地验证销毁证明
+func (withdraw *ConfidentialWithdraw) Verify() error {
+	statement := bp.NewWithdrawStatement(withdraw.CLn, withdraw.CRn, withdraw.PublicKey, withdraw.NonceU, withdraw.Epoch, withdraw.Sender.Bytes())
+	ok, err := bp.WithdrawVerify(statement, withdraw.Proof)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfidentialProof, err)
+	}
+	if !ok {
+		return ErrInvalidConfidentialProof
+	}
+	return nil
+}
+
+// 提交到合约的销毁数据
+type confidentialWithdrawData struct {
+	PublicKey string `json:"publicKey"`
+	Amount    uint64 `json:"amount"`
+	NonceU    string `json:"nonceU"`
+	Epoch     string `json:"epoch"`
+	Proof     string `json:"proof"`
+}
+
+// Marshal 将销毁请求编码为 json，合约根据公钥读取余额并用 Amount 计算 CLn
+func (withdraw *ConfidentialWithdraw) Marshal() string {
+	bytes, _ := json.Marshal(confidentialWithdrawData{
+		PublicKey: hexutil.Encode(withdraw.PublicKey.Marshal()),
+		Amount:    withdraw.Amount,
+		NonceU:    hexutil.Encode(withdraw.NonceU.Marshal()),
+		Epoch:     withdraw.Epoch.String(),
+		Proof:     withdraw.Proof.WdProofMarshal(),
+	})
+	return string(bytes)
+}
+
+// ConfidentialWalletClient 匿名资产钱包，将证明提交到 Contract 指定的合约
+type ConfidentialWalletClient struct {
+	ContractClient
+	// 合约地址或 cns 名称
+	Contract string
+	// 合约中接收转账和销毁证明的方法，参数为 json 编码的证明
+	TransferFunc string
+	BurnFunc     string
+}
+
+// abiPath 为合约的 abi 文件
+func NewConfidentialWalletClient(ctx context.Context, url URL, keyfilePath string, passphrase string, abiPath string, contract string) (*ConfidentialWalletClient, error) {
+	client, err := NewContractClient(ctx, url, keyfilePath, passphrase, abiPath, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	return &ConfidentialWalletClient{*client, contract, "transfer", "burn"}, nil
+}
+
+// 传入key 构造ConfidentialWallet客户端
+func NewConfidentialWalletClientWithKey(ctx context.Context, url URL, key *keystore.Key, abiPath string, contract string) (*ConfidentialWalletClient, error) {
+	client, err := NewContractClientWithKey(ctx, url, key, abiPath, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	return &ConfidentialWalletClient{*client, contract, "transfer", "burn"}, nil
+}
+
+// Transfer 在本地验证通过后提交匿名转账
+func (wallet ConfidentialWalletClient) Transfer(ctx context.Context, transfer *ConfidentialTransfer) (*packet.Receipt, error) {
+	if err := transfer.Verify(); err != nil {
+		return nil, err
+	}
+	return wallet.contractTransact(ctx, []string{transfer.Marshal()}, wallet.TransferFunc, wallet.Contract)
+}
+
+// Burn 在本地验证通过后提交销毁证明，证明中的发送者必须是钱包的账户
+func (wallet ConfidentialWalletClient) Burn(ctx context.Context, withdraw *ConfidentialWithdraw) (*packet.Receipt, error) {
+	if withdraw.Sender != wallet.Key.Address {
+		return nil, fmt.Errorf("the withdraw proof is bound to %s, not %s", withdraw.Sender.Hex(), wallet.Key.Address.Hex())
+	}
+	if err := withdraw.Verify(); err != nil {
+		return nil, err
+	}
+	return wallet.contractTransact(ctx, []string{withdraw.Marshal()}, wallet.BurnFunc, wallet.Contract)
+}
+
+func randIndex(n int) (int, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+	return int(index.Int64()), nil
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/crypto/bp"
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfidentialAbi = `[
+	{"name": "transfer", "inputs": [{"name": "data", "type": "string"}], "outputs": [], "constant": "false", "type": "function"},
+	{"name": "burn", "inputs": [{"name": "data", "type": "string"}], "outputs": [], "constant": "false", "type": "function"}
+]`
+
+func newTestConfidentialAccount(t *testing.T, value uint64) (*ConfidentialKey, ConfidentialAccount) {
+	key, err := GenerateConfidentialKey()
+	assert.NoError(t, err)
+	balance, err := key.Encrypt(value)
+	assert.NoError(t, err)
+	return key, ConfidentialAccount{PublicKey: key.Public, Balance: balance}
+}
+
+func newTestConfidentialTransfer(t *testing.T) (*ConfidentialTransfer, *ConfidentialKey, *ConfidentialKey) {
+	sender, senderAccount := newTestConfidentialAccount(t, 100)
+	receiver, receiverAccount := newTestConfidentialAccount(t, 5)
+	_, decoy1 := newTestConfidentialAccount(t, 7)
+	_, decoy2 := newTestConfidentialAccount(t, 9)
+	transfer, err := BuildConfidentialTransfer(ConfidentialTransferRequest{
+		Sender:   sender,
+		Balance:  senderAccount.Balance,
+		Value:    100,
+		Receiver: receiverAccount,
+		Decoys:   []ConfidentialAccount{decoy1, decoy2},
+		Amount:   30,
+		Epoch:    big.NewInt(3),
+	})
+	assert.NoError(t, err)
+	return transfer, sender, receiver
+}
+
+func TestBuildConfidentialTransfer(t *testing.T) {
+	transfer, sender, receiver := newTestConfidentialTransfer(t)
+	assert.NoError(t, transfer.Verify())
+
+	// 转账后发送方和接收方的新余额
+	for i, pub := range transfer.Statement.AnonPk {
+		for key, expect := range map[*ConfidentialKey]uint64{sender: 70, receiver: 35} {
+			if string(pub.Marshal()) != string(key.Public.Marshal()) {
+				continue
+			}
+			balance := bp.Newciphertext(transfer.Statement.CLnNew[i], transfer.Statement.CRnNew[i])
+			value, err := key.DecryptBalance(balance, MaxConfidentialAmount)
+			assert.NoError(t, err)
+			assert.Equal(t, expect, value)
+		}
+	}
+
+	decoded, err := UnmarshalConfidentialTransfer(transfer.Marshal())
+	assert.NoError(t, err)
+	assert.NoError(t, decoded.Verify())
+
+	// 篡改转账密文后验证失败
+	decoded.Statement.CVector[0], decoded.Statement.CVector[1] = decoded.Statement.CVector[1], decoded.Statement.CVector[0]
+	assert.ErrorIs(t, decoded.Verify(), ErrInvalidConfidentialProof)
+}
+
+func TestBuildConfidentialTransfer_Invalid(t *testing.T) {
+	sender, senderAccount := newTestConfidentialAccount(t, 10)
+	_, receiver := newTestConfidentialAccount(t, 0)
+	_, decoy := newTestConfidentialAccount(t, 0)
+	req := ConfidentialTransferRequest{Sender: sender, Balance: senderAccount.Balance, Value: 10, Receiver: receiver, Amount: 1, Epoch: big.NewInt(1)}
+
+	// 匿名集合的大小必须是 2 的幂
+	req.Decoys = []ConfidentialAccount{decoy}
+	_, err := BuildConfidentialTransfer(req)
+	assert.Error(t, err)
+
+	req.Decoys = nil
+	req.Amount = 11
+	_, err = BuildConfidentialTransfer(req)
+	assert.Error(t, err)
+
+	req.Amount, req.Value = 1, 9
+	_, err = BuildConfidentialTransfer(req)
+	assert.ErrorIs(t, err, ErrBalanceMismatch)
+
+	// 缺少密钥或者余额时返回错误
+	_, err = BuildConfidentialTransfer(ConfidentialTransferRequest{})
+	assert.Error(t, err)
+	req.Value = 10
+	noSender := req
+	noSender.Sender = nil
+	_, err = BuildConfidentialTransfer(noSender)
+	assert.Error(t, err)
+	noReceiver := req
+	noReceiver.Receiver.Balance = nil
+	_, err = BuildConfidentialTransfer(noReceiver)
+	assert.Error(t, err)
+	_, err = BuildConfidentialWithdraw(nil, senderAccount.Balance, 10, 1, big.NewInt(1), common.Address{})
+	assert.Error(t, err)
+}
+
+func TestConfidentialKey_DecryptBalance(t *testing.T) {
+	key, account := newTestConfidentialAccount(t, 42)
+	value, err := key.DecryptBalance(account.Balance, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), value)
+	_, err = key.DecryptBalance(account.Balance, 10)
+	assert.Error(t, err)
+
+	// 超过范围证明上限的余额不会被解出
+	overflow, err := key.Encrypt(MaxConfidentialAmount + 1)
+	assert.NoError(t, err)
+	_, err = key.DecryptBalance(overflow, math.MaxUint64)
+	assert.Error(t, err)
+
+	pub, err := ParseConfidentialPublicKey(key.PublicKeyHex())
+	assert.NoError(t, err)
+	assert.Equal(t, key.Public.Marshal(), pub.Marshal())
+	assert.Equal(t, key.Public.Marshal(), NewConfidentialKey(key.Private).Public.Marshal())
+}
+
+func TestBuildConfidentialWithdraw(t *testing.T) {
+	key, account := newTestConfidentialAccount(t, 50)
+	sender := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	withdraw, err := BuildConfidentialWithdraw(key, account.Balance, 50, 20, big.NewInt(2), sender)
+	assert.NoError(t, err)
+	assert.NoError(t, withdraw.Verify())
+
+	// 证明绑定了发送者
+	withdraw.Sender = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	assert.ErrorIs(t, withdraw.Verify(), ErrInvalidConfidentialProof)
+
+	_, err = BuildConfidentialWithdraw(key, account.Balance, 50, 51, big.NewInt(2), sender)
+	assert.Error(t, err)
+}
+
+// 模拟匿名资产合约，验证提交的证明后返回成功的回执
+type mockConfidentialWallet struct {
+	mockContract
+	calls []string
+}
+
+func (mock *mockConfidentialWallet) handlers(t *testing.T) map[string]mockHandler {
+	return mock.serve(t, nil, func(call *packet.DecodedCall) []*packet.Log {
+		if call.Method == "transfer" {
+			transfer, err := UnmarshalConfidentialTransfer(call.Params[0].Value.(string))
+			assert.NoError(t, err)
+			assert.NoError(t, transfer.Verify())
+		}
+		mock.calls = append(mock.calls, call.Method)
+		return nil
+	})
+}
+
+func TestConfidentialWalletClient(t *testing.T) {
+	abiPath := filepath.Join(t.TempDir(), "confidential.abi.json")
+	assert.NoError(t, os.WriteFile(abiPath, []byte(testConfidentialAbi), 0600))
+	mock := &mockConfidentialWallet{}
+	server, url := newMockNode(t, mock.handlers(t))
+	defer server.Close()
+	contract := "0x2000000000000000000000000000000000000002"
+	wallet, err := NewConfidentialWalletClientWithKey(context.Background(), url, newTestKey(t), abiPath, contract)
+	assert.NoError(t, err)
+	defer wallet.RpcClient.Close()
+	mock.contents = []packet.ContractContent{*wallet.ContractContent}
+	ctx := context.Background()
+
+	transfer, _, _ := newTestConfidentialTransfer(t)
+	receipt, err := wallet.Transfer(ctx, transfer)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, receipt.TransactionHash)
+
+	key, account := newTestConfidentialAccount(t, 50)
+	withdraw, err := BuildConfidentialWithdraw(key, account.Balance, 50, 20, big.NewInt(2), wallet.Key.Address)
+	assert.NoError(t, err)
+	_, err = wallet.Burn(ctx, withdraw)
+	assert.NoError(t, err)
+
+	// 绑定其他账户的销毁证明不会提交
+	other, err := BuildConfidentialWithdraw(key, account.Balance, 50, 20, big.NewInt(2), common.HexToAddress(contract))
+	assert.NoError(t, err)
+	_, err = wallet.Burn(ctx, other)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"transfer", "burn"}, mock.calls)
+}
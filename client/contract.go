@@ -319,6 +319,34 @@ func (contractClient ContractClient) contractCallWithParams(ctx context.Context,
 	return result, nil
 }
 
+// 封装合约的方法,发送交易并返回回执，交易执行失败时返回错误
+func (contractClient ContractClient) contractTransact(ctx context.Context, funcParams []string, funcName string, contract string) (*packet.Receipt, error) {
+	dataGenerator, err := contractClient.MakeContractGenerator(contract, funcParams, funcName)
+	if err != nil {
+		return nil, err
+	}
+	txparam, err := dataGenerator.MakeTxparamForContract(&contractClient.Key.Address, &dataGenerator.To)
+	if err != nil {
+		return nil, err
+	}
+	return contractClient.sendAndWaitReceipt(ctx, txparam)
+}
+
+func (contractClient ContractClient) sendAndWaitReceipt(ctx context.Context, txparam *common.TxParams) (*packet.Receipt, error) {
+	txHash, err := contractClient.Send(ctx, txparam, contractClient.Key)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := contractClient.GetReceiptByPolling(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Parsing().Status != packet.TxReceiptSuccessMsg {
+		return receipt, fmt.Errorf("the transaction %s failed with status %s", txHash, receipt.Status)
+	}
+	return receipt, nil
+}
+
 func getAbiBytes(abipath string) ([]byte, error) {
 	var abiBytes []byte
 	abiByte, err := packet.ParamParse(abipath, "abi")
@@ -378,8 +406,11 @@ func GetContractByAbiPath(abiPath string) (packet.ContractContent, error) {
 	return getContractContent(abiPath, "")
 }
 
-// 通过预编译合约的地址获得合约内容
+// 通过预编译合约的地址获得合约内容，包括运行时注册到 SystemContracts 中的合约
 func GetContractByContractAddress(precompiledContractAddress string) (packet.ContractContent, error) {
+	if contract, ok := SystemContracts.ByAddress(precompiledContractAddress); ok {
+		return contract.Content, nil
+	}
 	return getContractContent("", precompiledContractAddress)
 }
 
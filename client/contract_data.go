@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+// 可以迁移合约数据的角色，超级管理员和链管理员拥有合约管理员的权限，最终以链上的权限检查为准
+var contractDataRoles = []Role{RoleSuperAdmin, RoleChainAdmin, RoleContractAdmin}
+
+var ErrNoContractAdmin = errors.New("the account is not a contract admin")
+
+type ContractDataClient struct {
+	ContractClient
+}
+
+// ContractUpgrade 合约升级记录：迁移数据后将 cns 名称的 latest 重定向到新版本
+type ContractUpgrade struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	Src            string `json:"src"`
+	Dest           string `json:"dest"`
+	MigrateTxHash  string `json:"migrateTxHash"`
+	RedirectTxHash string `json:"redirectTxHash,omitempty"`
+	Redirected     bool   `json:"redirected"`
+}
+
+func NewContractDataClient(ctx context.Context, url URL, keyfilePath string, passphrase string) (*ContractDataClient, error) {
+	client, err := NewContractClient(ctx, url, keyfilePath, passphrase, precompile.ContractDataProcessorAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	contractDataClient := &ContractDataClient{
+		*client,
+	}
+	return contractDataClient, nil
+}
+
+// 传入key 构造ContractData客户端
+func NewContractDataClientWithKey(ctx context.Context, url URL, key *keystore.Key) (*ContractDataClient, error) {
+	client, err := NewContractClientWithKey(ctx, url, key, precompile.ContractDataProcessorAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	contractDataClient := &ContractDataClient{
+		*client,
+	}
+	return contractDataClient, nil
+}
+
+// Migrate 将合约 src 的数据迁移到合约 dest
+// 发送交易前检查当前账户是否为合约管理员以及两个合约是否存在，交易失败时返回错误
+func (contractDataClient ContractDataClient) Migrate(ctx context.Context, src string, dest string) (*packet.Receipt, error) {
+	if !packet.IsMatch(src, "address") || !packet.IsMatch(dest, "address") {
+		return nil, fmt.Errorf("invalid contract address %s or %s", src, dest)
+	}
+	if common.HexToAddress(src) == common.HexToAddress(dest) {
+		return nil, errors.New("the source and destination contracts are the same")
+	}
+	if err := contractDataClient.checkContractAdmin(ctx); err != nil {
+		return nil, err
+	}
+	for _, contract := range []string{src, dest} {
+		exist, err := contractDataClient.ContractExists(ctx, contract)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("the contract %s does not exist", contract)
+		}
+	}
+
+	receipt, err := contractDataClient.sysContractTransact(ctx, precompile.ContractDataProcessorAddress, "migrate", src, dest)
+	if err != nil {
+		return receipt, fmt.Errorf("migrate %s to %s error: %v", src, dest, err)
+	}
+	return receipt, nil
+}
+
+// Upgrade 将 cns 名称当前 latest 指向的合约数据迁移到 version 对应的合约，并将 latest 重定向到 version
+// 数据迁移后重定向失败时，返回的升级记录中 Redirected 为 false
+func (contractDataClient ContractDataClient) Upgrade(ctx context.Context, cnsClient *CnsClient, name string, version string) (*ContractUpgrade, error) {
+	src, err := cnsClient.resolve(ctx, name, "latest")
+	if err != nil {
+		return nil, err
+	}
+	dest, err := cnsClient.resolve(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	upgrade := &ContractUpgrade{Name: name, Version: version, Src: src, Dest: dest}
+	if !isRegisteredAddress(src) || !isRegisteredAddress(dest) {
+		return nil, fmt.Errorf("%s@latest or %s@%s is not registered: %w", name, name, version, ErrCnsNotRegistered)
+	}
+
+	receipt, err := contractDataClient.Migrate(ctx, src, dest)
+	if err != nil {
+		return nil, err
+	}
+	upgrade.MigrateTxHash = receipt.TransactionHash
+
+	receipt, err = cnsClient.cnsTransact(ctx, "cnsRedirect", name, version)
+	if receipt != nil {
+		upgrade.RedirectTxHash = receipt.TransactionHash
+	}
+	if err == nil {
+		var latest string
+		latest, err = cnsClient.resolve(ctx, name, "latest")
+		if err == nil && common.HexToAddress(latest) != common.HexToAddress(dest) {
+			err = fmt.Errorf("latest is resolved to %s, expect %s", latest, dest)
+		}
+	}
+	if err != nil {
+		return upgrade, fmt.Errorf("the data is migrated, but redirect %s to %s error: %v", name, version, err)
+	}
+	upgrade.Redirected = true
+	return upgrade, nil
+}
+
+func isRegisteredAddress(address string) bool {
+	return packet.IsMatch(address, "address") && common.HexToAddress(address) != (common.Address{})
+}
+
+// ContractExists 查询地址上是否部署了合约
+func (contractDataClient ContractDataClient) ContractExists(ctx context.Context, address string) (bool, error) {
+	code, err := contractDataClient.GetCode(ctx, address)
+	if err != nil {
+		return false, err
+	}
+	return len(code) > 0, nil
+}
+
+// 检查当前账户是否有合约管理员或者更高的角色
+func (contractDataClient ContractDataClient) checkContractAdmin(ctx context.Context) error {
+	content, err := GenContractContent(precompile.UserManagementAddress)
+	if err != nil {
+		return err
+	}
+	roleClient := RoleClient{ContractClient{contractDataClient.Client, &content, "wasm"}}
+	roles, err := roleClient.RolesOf(ctx, contractDataClient.Key.Address.Hex())
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		for _, allowed := range contractDataRoles {
+			if role == allowed {
+				return nil
+			}
+		}
+	}
+	return ErrNoContractAdmin
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// 模拟合约升级涉及的用户管理、cns 和数据迁移合约
+type mockUpgradeChain struct {
+	mockContract
+	roles      []string
+	codes      map[string]bool
+	versions   map[string]string
+	latest     string
+	migrations [][2]string
+}
+
+func (m *mockUpgradeChain) handlers(t *testing.T) map[string]mockHandler {
+	handlers := m.serve(t, func(call *packet.DecodedCall) interface{} {
+		switch call.Method {
+		case "getRolesByAddress":
+			return sysResult(append([]string{}, m.roles...))
+		case "getContractAddress":
+			version := call.Params[1].Value.(string)
+			if version == "latest" {
+				version = m.latest
+			}
+			address, ok := m.versions[version]
+			if !ok {
+				address = common.Address{}.Hex()
+			}
+			return wasmStringResult(address)
+		}
+		t.Errorf("unexpected call %s", call.Method)
+		return nil
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		switch call.Method {
+		case "migrate":
+			m.migrations = append(m.migrations, [2]string{call.Params[0].Value.(string), call.Params[1].Value.(string)})
+		case "cnsRedirect":
+			m.latest = call.Params[1].Value.(string)
+		}
+		return nil
+	})
+	handlers["eth_getCode"] = func(params []json.RawMessage) interface{} {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.codes[common.HexToAddress(mockParamString(params, 0)).Hex()] {
+			return "0x0061736d01000000"
+		}
+		return "0x"
+	}
+	return handlers
+}
+
+func TestContractDataClient_Upgrade(t *testing.T) {
+	v1 := "0x1000000000000000000000000000000000000011"
+	v2 := "0x1000000000000000000000000000000000000012"
+	m := &mockUpgradeChain{
+		codes:    map[string]bool{common.HexToAddress(v1).Hex(): true},
+		versions: map[string]string{"1.0.0.0": v1, "1.0.0.1": v2},
+		latest:   "1.0.0.0",
+	}
+	server, url := newMockNode(t, m.handlers(t))
+	defer server.Close()
+	key := newTestKey(t)
+	dataClient, err := NewContractDataClientWithKey(context.Background(), url, key)
+	assert.NoError(t, err)
+	defer dataClient.RpcClient.Close()
+	cnsClient, err := NewCnsClientWithKey(context.Background(), url, key, "wxbc")
+	assert.NoError(t, err)
+	defer cnsClient.RpcClient.Close()
+	ctx := context.Background()
+
+	_, err = dataClient.Migrate(ctx, v1, v2)
+	assert.Equal(t, ErrNoContractAdmin, err)
+
+	m.roles = []string{string(RoleContractDeployer)}
+	_, err = dataClient.Migrate(ctx, v1, v2)
+	assert.Equal(t, ErrNoContractAdmin, err)
+
+	// 超级管理员、链管理员和合约管理员都可以迁移
+	for _, role := range contractDataRoles {
+		m.roles = []string{string(RoleContractDeployer), string(role)}
+		_, err = dataClient.Migrate(ctx, v1, v2)
+		assert.EqualError(t, err, "the contract "+v2+" does not exist")
+	}
+	assert.Empty(t, m.migrations)
+
+	m.codes[common.HexToAddress(v2).Hex()] = true
+	_, err = dataClient.Upgrade(ctx, cnsClient, "wxbc", "2.0.0.0")
+	assert.ErrorIs(t, err, ErrCnsNotRegistered)
+
+	upgrade, err := dataClient.Upgrade(ctx, cnsClient, "wxbc", "1.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, upgrade.Redirected)
+	assert.NotEmpty(t, upgrade.MigrateTxHash)
+	assert.NotEmpty(t, upgrade.RedirectTxHash)
+	assert.Equal(t, [][2]string{{v1, v2}}, m.migrations)
+	assert.Equal(t, "1.0.0.1", m.latest)
+}
@@ -0,0 +1,346 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+// 一笔批量存证交易中 json 数据的最大字节数，超过时拆分为多笔交易
+var MaxEvidenceBatchBytes = 32 * 1024
+
+var ErrEvidenceNotFound = errors.New("the evidence is not found")
+
+type EvidenceClient struct {
+	ContractClient
+}
+
+// EvidenceRecord 存证记录，Key 为批量存证中的条目时 BatchKey 为批次的 key
+// SubmittedAt 为客户端获取到回执时的本地时间，不是区块的时间
+type EvidenceRecord struct {
+	Key         string    `json:"key"`
+	Digest      string    `json:"digest"`
+	Algorithm   string    `json:"algorithm"`
+	BatchKey    string    `json:"batchKey,omitempty"`
+	TxHash      string    `json:"txHash"`
+	BlockNumber uint64    `json:"blockNumber"`
+	BlockHash   string    `json:"blockHash"`
+	SubmittedAt time.Time `json:"submittedAt"`
+}
+
+func NewEvidenceClient(ctx context.Context, url URL, keyfilePath string, passphrase string) (*EvidenceClient, error) {
+	client, err := NewContractClient(ctx, url, keyfilePath, passphrase, precompile.EvidenceManagementAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	evidenceClient := &EvidenceClient{
+		*client,
+	}
+	return evidenceClient, nil
+}
+
+// 传入key 构造Evidence客户端
+func NewEvidenceClientWithKey(ctx context.Context, url URL, key *keystore.Key) (*EvidenceClient, error) {
+	client, err := NewContractClientWithKey(ctx, url, key, precompile.EvidenceManagementAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	evidenceClient := &EvidenceClient{
+		*client,
+	}
+	return evidenceClient, nil
+}
+
+// EvidenceHashAlgorithm 当前密码学算法下存证使用的哈希算法
+func EvidenceHashAlgorithm() string {
+	if crypto.IsGM() {
+		return "sm3"
+	}
+	return "keccak256"
+}
+
+// HashReader 计算数据流的哈希，国密模式下使用 SM3，否则使用 Keccak256
+func HashReader(r io.Reader) (string, error) {
+	h := crypto.NewHash()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hexutil.Encode(h.Sum(nil)), nil
+}
+
+// HashFile 计算文件的哈希
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return HashReader(f)
+}
+
+// SaveEvidence 将摘要保存到 key 下
+func (evidenceClient EvidenceClient) SaveEvidence(ctx context.Context, key string, digest string) (*EvidenceRecord, error) {
+	if key == "" {
+		return nil, errors.New("the evidence key is required")
+	}
+	receipt, err := evidenceClient.sysContractTransact(ctx, precompile.EvidenceManagementAddress, "saveEvidence", key, digest)
+	if err != nil {
+		return nil, fmt.Errorf("save the evidence %s error: %v", key, err)
+	}
+	return newEvidenceRecord(key, digest, "", receipt), nil
+}
+
+// SaveFile 计算文件的哈希并保存到 key 下
+func (evidenceClient EvidenceClient) SaveFile(ctx context.Context, key string, path string) (*EvidenceRecord, error) {
+	digest, err := HashFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return evidenceClient.SaveEvidence(ctx, key, digest)
+}
+
+// SaveReader 计算数据流的哈希并保存到 key 下
+func (evidenceClient EvidenceClient) SaveReader(ctx context.Context, key string, r io.Reader) (*EvidenceRecord, error) {
+	digest, err := HashReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return evidenceClient.SaveEvidence(ctx, key, digest)
+}
+
+// 批量存证分块的 key 为 batchKey#0、batchKey#1 等，batchKey 中不能包含该分隔符
+const evidenceChunkSeparator = "#"
+
+// batchKey 下保存的批量存证清单
+type evidenceBatchManifest struct {
+	Chunks int `json:"chunks"`
+}
+
+// SaveBatch 将多条摘要以 json 的形式分块保存，一笔交易放不下时拆分为多块，最后在 batchKey 下保存分块的数量
+// digests 为 key 到摘要的映射，返回每条摘要的存证记录
+func (evidenceClient EvidenceClient) SaveBatch(ctx context.Context, batchKey string, digests map[string]string) ([]EvidenceRecord, error) {
+	if batchKey == "" {
+		return nil, errors.New("the batch key is required")
+	}
+	if strings.Contains(batchKey, evidenceChunkSeparator) {
+		return nil, fmt.Errorf("the batch key %s contains %s", batchKey, evidenceChunkSeparator)
+	}
+	if len(digests) == 0 {
+		return nil, errors.New("the evidence batch is empty")
+	}
+	keys := make([]string, 0, len(digests))
+	for key := range digests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var (
+		records []EvidenceRecord
+		chunk   = make(map[string]string)
+		size    int
+		index   int
+	)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		key := evidenceChunkKey(batchKey, index)
+		bytes, _ := json.Marshal(chunk)
+		receipt, err := evidenceClient.sysContractTransact(ctx, precompile.EvidenceManagementAddress, "setJsonData", key, string(bytes))
+		if err != nil {
+			return fmt.Errorf("save the evidence batch %s error: %v", key, err)
+		}
+		for _, k := range keys {
+			if digest, ok := chunk[k]; ok {
+				records = append(records, *newEvidenceRecord(k, digest, batchKey, receipt))
+			}
+		}
+		chunk, size = make(map[string]string), 0
+		index++
+		return nil
+	}
+	for _, key := range keys {
+		entry := len(key) + len(digests[key]) + 6
+		if entry > MaxEvidenceBatchBytes {
+			return records, fmt.Errorf("the evidence %s is too large for a batch", key)
+		}
+		if size+entry > MaxEvidenceBatchBytes {
+			if err := flush(); err != nil {
+				return records, err
+			}
+		}
+		chunk[key] = digests[key]
+		size += entry
+	}
+	if err := flush(); err != nil {
+		return records, err
+	}
+	// 重新保存更少的分块时，清单保证不会读到之前多出的分块
+	bytes, _ := json.Marshal(evidenceBatchManifest{Chunks: index})
+	if _, err := evidenceClient.sysContractTransact(ctx, precompile.EvidenceManagementAddress, "setJsonData", batchKey, string(bytes)); err != nil {
+		return records, fmt.Errorf("save the manifest of the evidence batch %s error: %v", batchKey, err)
+	}
+	return records, nil
+}
+
+func evidenceChunkKey(batchKey string, index int) string {
+	return batchKey + evidenceChunkSeparator + strconv.Itoa(index)
+}
+
+func newEvidenceRecord(key, digest, batchKey string, receipt *packet.Receipt) *EvidenceRecord {
+	return &EvidenceRecord{
+		Key:         key,
+		Digest:      digest,
+		Algorithm:   EvidenceHashAlgorithm(),
+		BatchKey:    batchKey,
+		TxHash:      receipt.TransactionHash,
+		BlockNumber: receipt.Parsing().BlockNumber,
+		BlockHash:   receipt.BlockHash,
+		SubmittedAt: time.Now(),
+	}
+}
+
+// GetEvidence 查询 key 下保存的摘要
+func (evidenceClient EvidenceClient) GetEvidence(ctx context.Context, key string) (string, error) {
+	raw, err := evidenceClient.evidenceQuery(ctx, "getEvidence", key)
+	if err != nil {
+		return "", err
+	}
+	if raw == "" {
+		return "", ErrEvidenceNotFound
+	}
+	return raw, nil
+}
+
+// GetBatch 按照 batchKey 下的清单查询批量存证中的所有摘要
+func (evidenceClient EvidenceClient) GetBatch(ctx context.Context, batchKey string) (map[string]string, error) {
+	raw, err := evidenceClient.evidenceQuery(ctx, "getJsonData", batchKey)
+	if err != nil {
+		return nil, err
+	}
+	if raw == "" {
+		return nil, ErrEvidenceNotFound
+	}
+	var manifest evidenceBatchManifest
+	if err := ParseSysContractResult(raw, &manifest); err != nil || manifest.Chunks <= 0 {
+		return nil, fmt.Errorf("invalid manifest %s of the evidence batch %s", raw, batchKey)
+	}
+
+	digests := make(map[string]string)
+	for index := 0; index < manifest.Chunks; index++ {
+		raw, err := evidenceClient.evidenceQuery(ctx, "getJsonData", evidenceChunkKey(batchKey, index))
+		if err != nil {
+			return nil, err
+		}
+		if raw == "" {
+			return nil, fmt.Errorf("the chunk %d of the evidence batch %s is not found", index, batchKey)
+		}
+		chunk := make(map[string]string)
+		if err := ParseSysContractResult(raw, &chunk); err != nil {
+			return nil, fmt.Errorf("parse the evidence batch %s error: %v", batchKey, err)
+		}
+		for key, digest := range chunk {
+			digests[key] = digest
+		}
+	}
+	if len(digests) == 0 {
+		return nil, ErrEvidenceNotFound
+	}
+	return digests, nil
+}
+
+// evidenceQuery 查询存证合约，去掉查询结果外层的 {code,msg,data}
+func (evidenceClient EvidenceClient) evidenceQuery(ctx context.Context, funcName string, key string) (string, error) {
+	result, err := evidenceClient.contractCallWithParams(ctx, []string{key}, funcName, precompile.EvidenceManagementAddress)
+	if err != nil {
+		return "", err
+	}
+	res := result.([]interface{})
+	raw := strings.TrimSpace(res[0].(string))
+	var data string
+	if strings.HasPrefix(raw, "{") && ParseSysContractResult(raw, &data) == nil && data != "" {
+		return data, nil
+	}
+	return raw, nil
+}
+
+// Verify 重新计算文件的哈希，并与 key 下保存的摘要比较
+func (evidenceClient EvidenceClient) Verify(ctx context.Context, key string, path string) (bool, error) {
+	digest, err := HashFile(path)
+	if err != nil {
+		return false, err
+	}
+	stored, err := evidenceClient.GetEvidence(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(stored, digest), nil
+}
+
+// VerifyInBatch 重新计算文件的哈希，并与批量存证中 key 对应的摘要比较
+func (evidenceClient EvidenceClient) VerifyInBatch(ctx context.Context, batchKey string, key string, path string) (bool, error) {
+	digest, err := HashFile(path)
+	if err != nil {
+		return false, err
+	}
+	digests, err := evidenceClient.GetBatch(ctx, batchKey)
+	if err != nil {
+		return false, err
+	}
+	stored, ok := digests[key]
+	if !ok {
+		return false, ErrEvidenceNotFound
+	}
+	return strings.EqualFold(stored, digest), nil
+}
+
+// ExportEvidenceAudit 导出存证记录，包含交易哈希和区块信息
+// 文件扩展名为 .csv 时导出 csv，否则导出 json
+func ExportEvidenceAudit(path string, records []EvidenceRecord) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return WriteEvidenceAuditCSV(f, records)
+	}
+	encoder := json.NewEncoder(f)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// WriteEvidenceAuditCSV 以 csv 格式输出存证记录
+func WriteEvidenceAuditCSV(w io.Writer, records []EvidenceRecord) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"key", "digest", "algorithm", "batchKey", "txHash", "blockNumber", "blockHash", "submittedAt"})
+	for _, record := range records {
+		_ = writer.Write([]string{
+			record.Key,
+			record.Digest,
+			record.Algorithm,
+			record.BatchKey,
+			record.TxHash,
+			strconv.FormatUint(record.BlockNumber, 10),
+			record.BlockHash,
+			record.SubmittedAt.Format(time.RFC3339),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
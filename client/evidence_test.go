@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// 模拟存证合约
+type mockEvidence struct {
+	mockContract
+	values map[string]string
+	calls  []string
+}
+
+func (m *mockEvidence) handlers(t *testing.T) map[string]mockHandler {
+	return m.serve(t, func(call *packet.DecodedCall) interface{} {
+		value := m.values[call.Method+":"+call.Params[0].Value.(string)]
+		if call.Method == "getJsonData" && value != "" {
+			bytes, _ := json.Marshal(map[string]interface{}{"code": 0, "msg": "success", "data": value})
+			value = string(bytes)
+		}
+		return wasmStringResult(value)
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		m.calls = append(m.calls, call.Method)
+		getter := map[string]string{"saveEvidence": "getEvidence", "setJsonData": "getJsonData"}[call.Method]
+		m.values[getter+":"+call.Params[0].Value.(string)] = call.Params[1].Value.(string)
+		return []*packet.Log{testSysLog(precompile.EvidenceManagementAddress, "Notify", uint64(0), "success")}
+	})
+}
+
+func TestHashReader(t *testing.T) {
+	digest, err := HashReader(strings.NewReader("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, hexutil.Encode(crypto.Keccak256([]byte("hello"))), digest)
+	assert.Equal(t, "keccak256", EvidenceHashAlgorithm())
+}
+
+func TestEvidenceClient(t *testing.T) {
+	m := &mockEvidence{values: make(map[string]string)}
+	server, url := newMockNode(t, m.handlers(t))
+	defer server.Close()
+	evidenceClient, err := NewEvidenceClientWithKey(context.Background(), url, newTestKey(t))
+	assert.NoError(t, err)
+	defer evidenceClient.RpcClient.Close()
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "contract.pdf")
+	assert.NoError(t, os.WriteFile(file, []byte("contract v1"), 0600))
+
+	record, err := evidenceClient.SaveFile(ctx, "contract", file)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), record.BlockNumber)
+	assert.NotEmpty(t, record.BlockHash)
+	assert.NotEmpty(t, record.TxHash)
+	ok, err := evidenceClient.Verify(ctx, "contract", file)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	assert.NoError(t, os.WriteFile(file, []byte("contract v2"), 0600))
+	ok, err = evidenceClient.Verify(ctx, "contract", file)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = evidenceClient.GetEvidence(ctx, "missing")
+	assert.Equal(t, ErrEvidenceNotFound, err)
+
+	// 超过一笔交易的大小时拆分为多笔交易
+	defer func(limit int) { MaxEvidenceBatchBytes = limit }(MaxEvidenceBatchBytes)
+	MaxEvidenceBatchBytes = 200
+	digest, _ := HashFile(file)
+	digests := map[string]string{"a": digest, "b": digest, "c": digest, "d": digest}
+	records, err := evidenceClient.SaveBatch(ctx, "batch", digests)
+	assert.NoError(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, "batch", records[3].BatchKey)
+	assert.NotEqual(t, records[0].TxHash, records[3].TxHash)
+	assert.Equal(t, []string{"saveEvidence", "setJsonData", "setJsonData", "setJsonData"}, m.calls)
+
+	batch, err := evidenceClient.GetBatch(ctx, "batch")
+	assert.NoError(t, err)
+	assert.Equal(t, digests, batch)
+	ok, err = evidenceClient.VerifyInBatch(ctx, "batch", "d", file)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// 重新保存更少的摘要时不会读到之前多出的分块
+	_, err = evidenceClient.SaveBatch(ctx, "batch", map[string]string{"e": digest})
+	assert.NoError(t, err)
+	batch, err = evidenceClient.GetBatch(ctx, "batch")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"e": digest}, batch)
+	_, err = evidenceClient.SaveBatch(ctx, "batch#1", digests)
+	assert.Error(t, err)
+	_, err = evidenceClient.GetBatch(ctx, "contract")
+	assert.Equal(t, ErrEvidenceNotFound, err)
+
+	audit := filepath.Join(dir, "audit.csv")
+	assert.NoError(t, ExportEvidenceAudit(audit, append(records, *record)))
+	data, err := os.ReadFile(audit)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 6)
+	assert.Equal(t, "key,digest,algorithm,batchKey,txHash,blockNumber,blockHash,submittedAt", lines[0])
+	assert.Contains(t, lines[5], record.TxHash)
+	assert.Contains(t, lines[5], record.BlockHash)
+}
@@ -147,9 +147,10 @@ func (firewallClient FireWallClient) FwReset(ctx context.Context, action, target
 
 func (firewallClient FireWallClient) fwCommon(ctx context.Context, action, targetAddr, api, funcName string) (string, error) {
 	packet.ParamValid(action, "action")
-	packet.ParamValid(targetAddr, "fw")
-	packet.ParamValid(api, "name")
-	rules := packet.CombineRule(targetAddr, api) //TODO batch rules
+	rules, err := EncodeRules([]Rule{{Address: targetAddr, Api: api}})
+	if err != nil {
+		return "", err
+	}
 
 	funcParams := packet.CombineFuncParams(firewallClient.ContractAddress, action, rules)
 	result, err := firewallClient.contractCallWithParams(ctx, funcParams, funcName, precompile.FirewallManagementAddress)
@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"gopkg.in/yaml.v2"
+)
+
+// ContractFirewall 单个合约的防火墙策略
+type ContractFirewall struct {
+	Contract string `json:"contract" yaml:"contract"`
+	// 为空时不改变防火墙的开关状态
+	Enabled *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Accept  []Rule `json:"accept" yaml:"accept"`
+	Reject  []Rule `json:"reject" yaml:"reject"`
+}
+
+// FirewallPolicy 声明式的防火墙策略，可以保存为 yaml 或 json 文件
+type FirewallPolicy struct {
+	Contracts []ContractFirewall `json:"contracts" yaml:"contracts"`
+}
+
+// ParseFirewallPolicy 解析 yaml 或 json 格式的防火墙策略
+func ParseFirewallPolicy(data []byte) (*FirewallPolicy, error) {
+	policy := new(FirewallPolicy)
+	// json 是 yaml 的子集
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+	for _, contract := range policy.Contracts {
+		if !packet.IsMatch(getHexAddress(contract.Contract), "address") {
+			return nil, fmt.Errorf("invalid contract address %s in the firewall policy", contract.Contract)
+		}
+		// 非法的地址不能按照零地址处理
+		for _, rule := range append(append([]Rule{}, contract.Accept...), contract.Reject...) {
+			if err := rule.Validate(); err != nil {
+				return nil, fmt.Errorf("%v of the contract %s", err, contract.Contract)
+			}
+		}
+	}
+	return policy, nil
+}
+
+func LoadFirewallPolicy(filePath string) (*FirewallPolicy, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFirewallPolicy(data)
+}
+
+// WriteFile 保存防火墙策略，文件扩展名为 .json 时保存为 json，否则保存为 yaml
+func (policy *FirewallPolicy) WriteFile(filePath string) error {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasSuffix(strings.ToLower(filePath), ".json") {
+		data, err = json.MarshalIndent(policy, "", "  ")
+	} else {
+		data, err = yaml.Marshal(policy)
+	}
+	if err != nil {
+		return err
+	}
+	return packet.WriteFile(data, filePath)
+}
+
+// FirewallPlan 防火墙策略与链上状态的差异
+type FirewallPlan struct {
+	Contract string
+	// 需要打开或关闭防火墙时不为空
+	Enable    *bool
+	AddAccept []Rule
+	DelAccept []Rule
+	AddReject []Rule
+	DelReject []Rule
+}
+
+func (plan *FirewallPlan) Empty() bool {
+	return plan.Enable == nil && len(plan.AddAccept) == 0 && len(plan.DelAccept) == 0 &&
+		len(plan.AddReject) == 0 && len(plan.DelReject) == 0
+}
+
+// String 以 diff 的形式展示差异
+func (plan *FirewallPlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "contract %s\n", plan.Contract)
+	if plan.Enable != nil {
+		if *plan.Enable {
+			b.WriteString("~ firewall: closed -> open\n")
+		} else {
+			b.WriteString("~ firewall: open -> closed\n")
+		}
+	}
+	write := func(sign, action string, rules []Rule) {
+		for _, rule := range rules {
+			fmt.Fprintf(&b, "%s %s %s\n", sign, action, rule)
+		}
+	}
+	write("+", FwActionAccept, plan.AddAccept)
+	write("-", FwActionAccept, plan.DelAccept)
+	write("+", FwActionReject, plan.AddReject)
+	write("-", FwActionReject, plan.DelReject)
+	return b.String()
+}
+
+// forContract 针对另一个合约的防火墙客户端
+func (firewallClient FireWallClient) forContract(contract string) FireWallClient {
+	firewallClient.ContractAddress = getHexAddress(contract)
+	return firewallClient
+}
+
+// ExportPolicy 导出合约当前的防火墙状态作为策略
+func (firewallClient FireWallClient) ExportPolicy(ctx context.Context, contracts ...string) (*FirewallPolicy, error) {
+	if len(contracts) == 0 {
+		contracts = []string{firewallClient.ContractAddress}
+	}
+	policy := new(FirewallPolicy)
+	for _, contract := range contracts {
+		status, err := firewallClient.forContract(contract).GetFirewallStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		enabled := status.Enabled
+		policy.Contracts = append(policy.Contracts, ContractFirewall{
+			Contract: getHexAddress(contract),
+			Enabled:  &enabled,
+			Accept:   status.AcceptList,
+			Reject:   status.RejectList,
+		})
+	}
+	return policy, nil
+}
+
+// Plan 对比策略和链上的防火墙状态，返回每个合约需要的变更
+func (firewallClient FireWallClient) Plan(ctx context.Context, policy *FirewallPolicy) ([]*FirewallPlan, error) {
+	plans := make([]*FirewallPlan, 0, len(policy.Contracts))
+	for _, cf := range policy.Contracts {
+		client := firewallClient.forContract(cf.Contract)
+		status, err := client.GetFirewallStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		plan := &FirewallPlan{Contract: client.ContractAddress}
+		if cf.Enabled != nil && *cf.Enabled != status.Enabled {
+			enable := *cf.Enabled
+			plan.Enable = &enable
+		}
+		plan.AddAccept, plan.DelAccept = diffRules(cf.Accept, status.AcceptList)
+		plan.AddReject, plan.DelReject = diffRules(cf.Reject, status.RejectList)
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// diffRules 返回需要新增和删除的规则
+func diffRules(desired []Rule, live []Rule) (add []Rule, del []Rule) {
+	want := ruleSet(desired)
+	have := ruleSet(live)
+	for rule := range want {
+		if !have[rule] {
+			add = append(add, rule)
+		}
+	}
+	for rule := range have {
+		if !want[rule] {
+			del = append(del, rule)
+		}
+	}
+	sortRules(add)
+	sortRules(del)
+	return add, del
+}
+
+func ruleSet(rules []Rule) map[Rule]bool {
+	set := make(map[Rule]bool)
+	for _, rule := range rules {
+		set[rule.normalize()] = true
+	}
+	return set
+}
+
+func sortRules(rules []Rule) {
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].String() < rules[j].String()
+	})
+}
+
+// Apply 按照计划发送最少的交易，同一类变更的规则合并到一笔交易中，完成后重新对比链上的状态
+// 先新增允许规则、删除拒绝规则，再删除旧的允许规则、新增拒绝规则，最后再打开防火墙，避免中间状态拒绝合法的调用
+// 防火墙合约的事件 code 不为 0 时立即返回错误
+func (firewallClient FireWallClient) Apply(ctx context.Context, plans []*FirewallPlan) error {
+	for _, plan := range plans {
+		client := firewallClient.forContract(plan.Contract)
+		if plan.Enable != nil && !*plan.Enable {
+			if _, err := client.sysContractTransact(ctx, precompile.FirewallManagementAddress, "__sys_FwClose", client.ContractAddress); err != nil {
+				return fmt.Errorf("close the firewall of %s error: %v", plan.Contract, err)
+			}
+		}
+		steps := []struct {
+			funcName string
+			action   string
+			rules    []Rule
+		}{
+			{"__sys_FwAdd", FwActionAccept, plan.AddAccept},
+			{"__sys_FwDel", FwActionReject, plan.DelReject},
+			{"__sys_FwDel", FwActionAccept, plan.DelAccept},
+			{"__sys_FwAdd", FwActionReject, plan.AddReject},
+		}
+		for _, step := range steps {
+			if len(step.rules) == 0 {
+				continue
+			}
+			rules, err := EncodeRules(step.rules)
+			if err != nil {
+				return err
+			}
+			if _, err := client.sysContractTransact(ctx, precompile.FirewallManagementAddress, step.funcName, client.ContractAddress, step.action, rules); err != nil {
+				return fmt.Errorf("%s %s rules of %s error: %v", step.funcName, step.action, plan.Contract, err)
+			}
+		}
+		if plan.Enable != nil && *plan.Enable {
+			if _, err := client.sysContractTransact(ctx, precompile.FirewallManagementAddress, "__sys_FwOpen", client.ContractAddress); err != nil {
+				return fmt.Errorf("open the firewall of %s error: %v", plan.Contract, err)
+			}
+		}
+	}
+	return firewallClient.verify(ctx, plans)
+}
+
+func (firewallClient FireWallClient) verify(ctx context.Context, plans []*FirewallPlan) error {
+	for _, plan := range plans {
+		client := firewallClient.forContract(plan.Contract)
+		status, err := client.GetFirewallStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if plan.Enable != nil && status.Enabled != *plan.Enable {
+			return fmt.Errorf("the firewall of %s is not switched", plan.Contract)
+		}
+		check := func(action string, live []Rule, add, del []Rule) error {
+			have := ruleSet(live)
+			for _, rule := range add {
+				if !have[rule] {
+					return fmt.Errorf("the %s rule %s of %s is not added", action, rule, plan.Contract)
+				}
+			}
+			for _, rule := range del {
+				if have[rule] {
+					return fmt.Errorf("the %s rule %s of %s is not deleted", action, rule, plan.Contract)
+				}
+			}
+			return nil
+		}
+		if err = check(FwActionAccept, status.AcceptList, plan.AddAccept, plan.DelAccept); err != nil {
+			return err
+		}
+		if err = check(FwActionReject, status.RejectList, plan.AddReject, plan.DelReject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
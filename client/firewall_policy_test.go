@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const testFwContract = "0x6988decc03a2d38888534ad0b4a33a267b34807d"
+
+// 模拟防火墙合约，记录收到的交易，denied 中的方法返回权限错误
+type mockFirewall struct {
+	mockContract
+	active bool
+	lists  map[string][]map[string]string
+	calls  []string
+	denied map[string]bool
+}
+
+func newMockFirewall() *mockFirewall {
+	return &mockFirewall{
+		lists: map[string][]map[string]string{FwActionAccept: nil, FwActionReject: nil},
+	}
+}
+
+func (m *mockFirewall) status() string {
+	data, _ := json.Marshal(map[string]interface{}{
+		"ContractAddress": testFwContract,
+		"Active":          m.active,
+		"AcceptedList":    m.lists[FwActionAccept],
+		"RejectedList":    m.lists[FwActionReject],
+	})
+	return string(data)
+}
+
+func (m *mockFirewall) handlers(t *testing.T) map[string]mockHandler {
+	return m.serve(t, func(call *packet.DecodedCall) interface{} {
+		return wasmStringResult(m.status())
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		name := call.Method
+		if len(call.Params) > 1 {
+			name += " " + call.Params[1].Value.(string)
+		}
+		m.calls = append(m.calls, name)
+		if m.denied[call.Method] {
+			return []*packet.Log{testSysLog(precompile.FirewallManagementAddress, call.Method, uint64(1), "permission denied")}
+		}
+		m.apply(call)
+		return []*packet.Log{testSysLog(precompile.FirewallManagementAddress, call.Method, uint64(0), "success")}
+	})
+}
+
+func (m *mockFirewall) apply(call *packet.DecodedCall) {
+	switch call.Method {
+	case "__sys_FwOpen":
+		m.active = true
+	case "__sys_FwClose":
+		m.active = false
+	case "__sys_FwAdd", "__sys_FwDel":
+		action := call.Params[1].Value.(string)
+		for _, rule := range strings.Split(call.Params[2].Value.(string), "|") {
+			parts := strings.SplitN(rule, ":", 2)
+			addr := parts[0]
+			if addr == FwWildcard {
+				addr = "0xffffffffffffffffffffffffffffffffffffffff"
+			}
+			elem := map[string]string{"Addr": addr, "FuncName": parts[1]}
+			list := m.lists[action][:0:0]
+			for _, e := range m.lists[action] {
+				if !strings.EqualFold(e["Addr"], elem["Addr"]) || e["FuncName"] != elem["FuncName"] {
+					list = append(list, e)
+				}
+			}
+			if call.Method == "__sys_FwAdd" {
+				list = append(list, elem)
+			}
+			m.lists[action] = list
+		}
+	}
+}
+
+const testFwPolicy = `
+contracts:
+  - contract: 0x6988decc03a2d38888534ad0b4a33a267b34807d
+    enabled: true
+    accept:
+      - address: "*"
+        api: getName
+      - address: 0x60cEaB6ec8fD5bAb8B6B46D54a3A2a5B83EbdE10
+        api: setName
+    reject:
+      - address: 0x1000000000000000000000000000000000000001
+        api: "*"
+`
+
+func TestParseFirewallPolicy(t *testing.T) {
+	_, err := ParseFirewallPolicy([]byte(testFwPolicy))
+	assert.NoError(t, err)
+	// 规则中拼写错误的地址不能被当作零地址应用
+	_, err = ParseFirewallPolicy([]byte(strings.Replace(testFwPolicy, "0x60cEaB6ec8fD5bAb8B6B46D54a3A2a5B83EbdE10", "foo", 1)))
+	assert.Error(t, err)
+	_, err = ParseFirewallPolicy([]byte(strings.Replace(testFwPolicy, "api: \"*\"", "api: \"get-name\"", 1)))
+	assert.Error(t, err)
+}
+
+func TestFireWallClient_PlanAndApply(t *testing.T) {
+	fw := newMockFirewall()
+	fw.lists[FwActionAccept] = []map[string]string{
+		{"Addr": "0xffffffffffffffffffffffffffffffffffffffff", "FuncName": "getName"},
+		{"Addr": "0x2000000000000000000000000000000000000002", "FuncName": "setName"},
+	}
+	server, url := newMockNode(t, fw.handlers(t))
+	defer server.Close()
+	fwClient, err := NewFireWallClientWithKey(context.Background(), url, newTestKey(t), testFwContract)
+	assert.NoError(t, err)
+	defer fwClient.RpcClient.Close()
+
+	policy, err := ParseFirewallPolicy([]byte(testFwPolicy))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	plans, err := fwClient.Plan(ctx, policy)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plans))
+	plan := plans[0]
+	assert.True(t, *plan.Enable)
+	assert.Equal(t, []Rule{{Address: "0x60ceab6ec8fd5bab8b6b46d54a3a2a5b83ebde10", Api: "setName"}}, plan.AddAccept)
+	assert.Equal(t, []Rule{{Address: "0x2000000000000000000000000000000000000002", Api: "setName"}}, plan.DelAccept)
+	assert.Equal(t, []Rule{{Address: "0x1000000000000000000000000000000000000001", Api: "*"}}, plan.AddReject)
+	assert.Empty(t, plan.DelReject)
+	assert.Contains(t, plan.String(), "+ accept 0x60ceab6ec8fd5bab8b6b46d54a3a2a5b83ebde10:setName")
+
+	// 没有权限时在第一笔交易后停止
+	fw.denied = map[string]bool{"__sys_FwAdd": true}
+	err = fwClient.Apply(ctx, plans)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+	assert.Equal(t, []string{"__sys_FwAdd accept"}, fw.calls)
+	fw.denied, fw.calls = nil, nil
+
+	// 先新增允许规则再删除旧的允许规则
+	assert.NoError(t, fwClient.Apply(ctx, plans))
+	assert.Equal(t, []string{"__sys_FwAdd accept", "__sys_FwDel accept", "__sys_FwAdd reject", "__sys_FwOpen"}, fw.calls)
+
+	plans, err = fwClient.Plan(ctx, policy)
+	assert.NoError(t, err)
+	assert.True(t, plans[0].Empty())
+
+	// 导出的策略可以原样写回文件
+	exported, err := fwClient.ExportPolicy(ctx)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "firewall.yaml")
+	assert.NoError(t, exported.WriteFile(path))
+	loaded, err := LoadFirewallPolicy(path)
+	assert.NoError(t, err)
+	plans, err = fwClient.Plan(ctx, loaded)
+	assert.NoError(t, err)
+	assert.True(t, plans[0].Empty())
+	assert.Equal(t, common.HexToAddress(testFwContract).Hex(), common.HexToAddress(loaded.Contracts[0].Contract).Hex())
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	common_venachain "github.com/Venachain/client-sdk-go/venachain/common"
+)
+
+const (
+	FwActionAccept = "accept"
+	FwActionReject = "reject"
+
+	// 防火墙规则中表示所有账户或者所有接口的通配符
+	FwWildcard = "*"
+
+	// 多条规则之间的分隔符
+	fwRuleSeparator = "|"
+)
+
+// 链上用该地址表示所有账户
+var fwWildcardAddress = common_venachain.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff")
+
+// Rule 防火墙规则，Address 为调用者的账户地址，Api 为合约接口名，都可以为通配符 *
+type Rule struct {
+	Address string `json:"address" yaml:"address"`
+	Api     string `json:"api" yaml:"api"`
+}
+
+// ParseRule 解析 address:api 格式的规则
+func ParseRule(str string) (Rule, error) {
+	parts := strings.Split(strings.TrimSpace(str), ":")
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid firewall rule %s, should be <address>:<api>", str)
+	}
+	rule := Rule{Address: parts[0], Api: parts[1]}
+	if err := rule.Validate(); err != nil {
+		return Rule{}, err
+	}
+	return rule.normalize(), nil
+}
+
+// ParseRules 解析以 | 分隔的多条规则
+func ParseRules(str string) ([]Rule, error) {
+	if strings.TrimSpace(str) == "" {
+		return nil, nil
+	}
+	var rules []Rule
+	for _, s := range strings.Split(str, fwRuleSeparator) {
+		rule, err := ParseRule(s)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// EncodeRules 将规则编码为链上接口使用的格式
+func EncodeRules(rules []Rule) (string, error) {
+	strs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return "", err
+		}
+		strs = append(strs, rule.normalize().String())
+	}
+	return strings.Join(strs, fwRuleSeparator), nil
+}
+
+// Validate 地址必须为合法的账户地址或 *，接口名必须为合法的函数名或 *
+func (rule Rule) Validate() error {
+	address, api := strings.TrimSpace(rule.Address), strings.TrimSpace(rule.Api)
+	if address != FwWildcard && !packet.IsMatch(address, "address") {
+		return fmt.Errorf("invalid address %s in the firewall rule", rule.Address)
+	}
+	if api == "" || (api != FwWildcard && !packet.IsMatch(api, "name")) {
+		return fmt.Errorf("invalid api %s in the firewall rule", rule.Api)
+	}
+	return nil
+}
+
+func (rule Rule) String() string {
+	return packet.CombineRule(rule.Address, rule.Api)
+}
+
+func (rule Rule) IsWildcardAddress() bool {
+	return rule.normalize().Address == FwWildcard
+}
+
+func (rule Rule) IsWildcardApi() bool {
+	return strings.TrimSpace(rule.Api) == FwWildcard
+}
+
+// Matches 规则是否匹配调用者和接口
+func (rule Rule) Matches(caller, api string) bool {
+	rule = rule.normalize()
+	if rule.Address != FwWildcard && !strings.EqualFold(rule.Address, common_venachain.HexToAddress(caller).Hex()) {
+		return false
+	}
+	return rule.Api == FwWildcard || rule.Api == api
+}
+
+// normalize 统一地址的大小写和通配符的表示，用于比较规则
+func (rule Rule) normalize() Rule {
+	address := strings.TrimSpace(rule.Address)
+	if address != FwWildcard {
+		addr := common_venachain.HexToAddress(address)
+		if addr == fwWildcardAddress {
+			address = FwWildcard
+		} else {
+			address = strings.ToLower(addr.Hex())
+		}
+	}
+	return Rule{Address: address, Api: strings.TrimSpace(rule.Api)}
+}
+
+// FirewallStatus 合约的防火墙状态
+type FirewallStatus struct {
+	Contract   string `json:"contract"`
+	Enabled    bool   `json:"enabled"`
+	AcceptList []Rule `json:"acceptList"`
+	RejectList []Rule `json:"rejectList"`
+}
+
+// 链上 __sys_FwStatus 的返回值，通配的账户地址可能是 * 或者全 f 的地址，使用字符串解析
+type fwStatusRaw struct {
+	ContractAddress string
+	Active          bool
+	AcceptedList    []fwStatusElem
+	RejectedList    []fwStatusElem
+}
+
+type fwStatusElem struct {
+	Addr     string
+	FuncName string
+}
+
+// ParseFirewallStatus 解析 __sys_FwStatus 的返回值
+func ParseFirewallStatus(raw string) (*FirewallStatus, error) {
+	var status fwStatusRaw
+	if err := json.Unmarshal([]byte(raw), &status); err != nil {
+		return nil, fmt.Errorf("parse the firewall status error: %v", err)
+	}
+	convert := func(elems []fwStatusElem) []Rule {
+		rules := make([]Rule, 0, len(elems))
+		for _, elem := range elems {
+			rules = append(rules, Rule{Address: elem.Addr, Api: elem.FuncName}.normalize())
+		}
+		return rules
+	}
+	return &FirewallStatus{
+		Contract:   status.ContractAddress,
+		Enabled:    status.Active,
+		AcceptList: convert(status.AcceptedList),
+		RejectList: convert(status.RejectedList),
+	}, nil
+}
+
+// FirewallDecision 防火墙对一次调用的判定结果，Rule 为命中的规则
+type FirewallDecision struct {
+	Allowed bool
+	Rule    *Rule
+	Reason  string
+}
+
+// Evaluate 按照链上的规则判定调用：防火墙关闭时全部允许，
+// 否则先匹配拒绝列表，再匹配允许列表，都不匹配时拒绝
+func (status *FirewallStatus) Evaluate(caller, api string) FirewallDecision {
+	if !status.Enabled {
+		return FirewallDecision{Allowed: true, Reason: "the firewall is closed"}
+	}
+	for i := range status.RejectList {
+		if rule := status.RejectList[i]; rule.Matches(caller, api) {
+			return FirewallDecision{Rule: &rule, Reason: fmt.Sprintf("rejected by the rule %s", rule)}
+		}
+	}
+	for i := range status.AcceptList {
+		if rule := status.AcceptList[i]; rule.Matches(caller, api) {
+			return FirewallDecision{Allowed: true, Rule: &rule, Reason: fmt.Sprintf("accepted by the rule %s", rule)}
+		}
+	}
+	return FirewallDecision{Reason: "no accept rule matches the caller and the api"}
+}
+
+// IsCallAllowed 判断 caller 是否可以调用合约的 api
+func (status *FirewallStatus) IsCallAllowed(caller, api string) bool {
+	return status.Evaluate(caller, api).Allowed
+}
+
+// GetFirewallStatus 查询合约的防火墙状态
+func (firewallClient FireWallClient) GetFirewallStatus(ctx context.Context) (*FirewallStatus, error) {
+	raw, err := firewallClient.FwStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status, err := ParseFirewallStatus(raw)
+	if err != nil {
+		return nil, fmt.Errorf("the firewall status of %s: %v", firewallClient.ContractAddress, err)
+	}
+	if status.Contract == "" {
+		status.Contract = firewallClient.ContractAddress
+	}
+	return status, nil
+}
+
+// IsCallAllowed 查询 contract 的防火墙状态，并判定 caller 是否可以调用 api
+func (firewallClient FireWallClient) IsCallAllowed(ctx context.Context, contract, caller, api string) (FirewallDecision, error) {
+	status, err := firewallClient.forContract(contract).GetFirewallStatus(ctx)
+	if err != nil {
+		return FirewallDecision{}, err
+	}
+	return status.Evaluate(caller, api), nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("*:getName|0x60cEaB6ec8fD5bAb8B6B46D54a3A2a5B83EbdE10:*|0xffffffffffffffffffffffffffffffffffffffff:setName")
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Address: "*", Api: "getName"},
+		{Address: "0x60ceab6ec8fd5bab8b6b46d54a3a2a5b83ebde10", Api: "*"},
+		{Address: "*", Api: "setName"},
+	}, rules)
+	assert.True(t, rules[0].IsWildcardAddress())
+	assert.True(t, rules[1].IsWildcardApi())
+
+	for _, invalid := range []string{"getName", "0x123:getName", "*:", "*:get-name", "*:a:b"} {
+		_, err = ParseRule(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	encoded, err := EncodeRules(rules)
+	assert.NoError(t, err)
+	assert.Equal(t, "*:getName|0x60ceab6ec8fd5bab8b6b46d54a3a2a5b83ebde10:*|*:setName", encoded)
+	// 非法的地址不会被当作零地址
+	_, err = EncodeRules([]Rule{{Address: "foo", Api: "getName"}})
+	assert.Error(t, err)
+}
+
+func TestFirewallStatus_Evaluate(t *testing.T) {
+	raw := `{"ContractAddress":"0x6988decc03a2d38888534ad0b4a33a267b34807d","Active":true,
+		"AcceptedList":[{"Addr":"0xffffffffffffffffffffffffffffffffffffffff","FuncName":"getName"},
+			{"Addr":"0x60cEaB6ec8fD5bAb8B6B46D54a3A2a5B83EbdE10","FuncName":"*"}],
+		"RejectedList":[{"Addr":"0x60cEaB6ec8fD5bAb8B6B46D54a3A2a5B83EbdE10","FuncName":"kill"}]}`
+	status, err := ParseFirewallStatus(raw)
+	assert.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, Rule{Address: "*", Api: "getName"}, status.AcceptList[0])
+
+	owner := "0x60ceab6ec8fd5bab8b6b46d54a3a2a5b83ebde10"
+	other := "0x1000000000000000000000000000000000000001"
+	assert.True(t, status.IsCallAllowed(other, "getName"))
+	assert.False(t, status.IsCallAllowed(other, "setName"))
+	assert.True(t, status.IsCallAllowed(owner, "setName"))
+
+	decision := status.Evaluate(owner, "kill")
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, &Rule{Address: owner, Api: "kill"}, decision.Rule)
+	assert.Contains(t, decision.Reason, "rejected")
+
+	status.Enabled = false
+	assert.True(t, status.IsCallAllowed(other, "kill"))
+}
+
+func TestFireWallClient_IsCallAllowed(t *testing.T) {
+	fw := newMockFirewall()
+	fw.active = true
+	fw.lists[FwActionAccept] = []map[string]string{{"Addr": "0xffffffffffffffffffffffffffffffffffffffff", "FuncName": "getName"}}
+	server, url := newMockNode(t, fw.handlers(t))
+	defer server.Close()
+	fwClient, err := NewFireWallClientWithKey(context.Background(), url, newTestKey(t), testFwContract)
+	assert.NoError(t, err)
+	defer fwClient.RpcClient.Close()
+
+	decision, err := fwClient.IsCallAllowed(context.Background(), testFwContract, "0x1000000000000000000000000000000000000001", "getName")
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	_, err = fwClient.FwNew(context.Background(), FwActionAccept, "0x123", "setName")
+	assert.Error(t, err)
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+var ErrNoGroupPermission = errors.New("the account has no permission to operate groups")
+
+type GroupClient struct {
+	ContractClient
+	GroupID uint64
+}
+
+// GroupTxResult 群组合约交易的结果，Events 为回执中解析出的群组合约事件
+type GroupTxResult struct {
+	TxHash      string
+	BlockNumber uint64
+	Events      []SysEvent
+}
+
+func NewGroupClient(ctx context.Context, url URL, keyfilePath string, passphrase string, groupID uint64) (*GroupClient, error) {
+	client, err := NewContractClient(ctx, url, keyfilePath, passphrase, precompile.GroupManagementAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	groupClient := &GroupClient{
+		*client,
+		groupID,
+	}
+	return groupClient, nil
+}
+
+// 传入key 构造Group客户端
+func NewGroupClientWithKey(ctx context.Context, url URL, key *keystore.Key, groupID uint64) (*GroupClient, error) {
+	client, err := NewContractClientWithKey(ctx, url, key, precompile.GroupManagementAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	groupClient := &GroupClient{
+		*client,
+		groupID,
+	}
+	return groupClient, nil
+}
+
+// 查询当前账户是否有群组的操作权限
+func (groupClient GroupClient) HasGroupOpPermission(ctx context.Context) (bool, error) {
+	result, err := groupClient.contractCallWithParams(ctx, nil, "hasGroupOpPermission", precompile.GroupManagementAddress)
+	if err != nil {
+		return false, err
+	}
+	res := result.([]interface{})
+	return res[0].(int32) == 1, nil
+}
+
+// 创建群组，群组号使用客户端的 GroupID
+func (groupClient GroupClient) GroupCreate(ctx context.Context, info syscontracts.GroupInfo) (*GroupTxResult, error) {
+	info.GroupID = groupClient.GroupID
+	for _, node := range info.BootNodes {
+		if err := validBootNode(node); err != nil {
+			return nil, err
+		}
+	}
+	bytes, _ := json.Marshal(info)
+	return groupClient.groupTransact(ctx, "createGroup", string(bytes))
+}
+
+// 添加群组的引导节点
+func (groupClient GroupClient) GroupAddBootNode(ctx context.Context, node syscontracts.BootNode) (*GroupTxResult, error) {
+	if err := validBootNode(node); err != nil {
+		return nil, err
+	}
+	bytes, _ := json.Marshal(node)
+	return groupClient.groupTransact(ctx, "addBootNode", groupClient.groupIDParam(), string(bytes))
+}
+
+// 删除群组的引导节点
+func (groupClient GroupClient) GroupDelBootNode(ctx context.Context, node syscontracts.BootNode) (*GroupTxResult, error) {
+	if node.PublicKey == "" {
+		return nil, errors.New("the public key of the boot node is required")
+	}
+	bytes, _ := json.Marshal(node)
+	return groupClient.groupTransact(ctx, "delBootNode", groupClient.groupIDParam(), string(bytes))
+}
+
+// 使用 nodes 替换群组所有的引导节点
+func (groupClient GroupClient) GroupUpdateBootNodes(ctx context.Context, nodes []syscontracts.BootNode) (*GroupTxResult, error) {
+	for _, node := range nodes {
+		if err := validBootNode(node); err != nil {
+			return nil, err
+		}
+	}
+	if nodes == nil {
+		nodes = []syscontracts.BootNode{}
+	}
+	bytes, _ := json.Marshal(nodes)
+	return groupClient.groupTransact(ctx, "updateBootNodes", groupClient.groupIDParam(), string(bytes))
+}
+
+// 查询客户端 GroupID 对应的群组
+func (groupClient GroupClient) GroupQuery(ctx context.Context) (*syscontracts.GroupInfo, error) {
+	raw, err := groupClient.groupQuery(ctx, "getGroupByID", groupClient.groupIDParam())
+	if err != nil {
+		return nil, err
+	}
+	var group syscontracts.GroupInfo
+	if err := ParseSysContractResult(raw, &group); err != nil {
+		return nil, fmt.Errorf("query the group %d error: %v", groupClient.GroupID, err)
+	}
+	return &group, nil
+}
+
+// 查询所有的群组
+func (groupClient GroupClient) GroupQueryAll(ctx context.Context) ([]syscontracts.GroupInfo, error) {
+	raw, err := groupClient.groupQuery(ctx, "getAllGroups")
+	if err != nil {
+		return nil, err
+	}
+	var groups []syscontracts.GroupInfo
+	if err := ParseSysContractResult(raw, &groups); err != nil {
+		return nil, fmt.Errorf("query all groups error: %v", err)
+	}
+	return groups, nil
+}
+
+func (groupClient GroupClient) groupIDParam() string {
+	return strconv.FormatUint(groupClient.GroupID, 10)
+}
+
+func (groupClient GroupClient) groupQuery(ctx context.Context, funcName string, funcParams ...string) (string, error) {
+	result, err := groupClient.contractCallWithParams(ctx, funcParams, funcName, precompile.GroupManagementAddress)
+	if err != nil {
+		return "", err
+	}
+	res := result.([]interface{})
+	return res[0].(string), nil
+}
+
+// groupTransact 检查当前账户的群组操作权限后发送交易，并解析回执中的群组合约事件
+func (groupClient GroupClient) groupTransact(ctx context.Context, funcName string, funcParams ...string) (*GroupTxResult, error) {
+	permitted, err := groupClient.HasGroupOpPermission(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !permitted {
+		return nil, ErrNoGroupPermission
+	}
+
+	receipt, err := groupClient.sysContractTransact(ctx, precompile.GroupManagementAddress, funcName, funcParams...)
+	if receipt == nil {
+		return nil, err
+	}
+	result := &GroupTxResult{
+		TxHash:      receipt.TransactionHash,
+		BlockNumber: receipt.Parsing().BlockNumber,
+	}
+	// 事件无法解析时 sysContractTransact 已经返回了错误
+	result.Events, _ = ParseSysEvents(receipt, groupClient.ContractContent.GetEvents())
+	if err != nil {
+		return result, fmt.Errorf("%s error: %v", funcName, err)
+	}
+	return result, nil
+}
+
+func validBootNode(node syscontracts.BootNode) error {
+	if node.PublicKey == "" || node.ExternalIP == "" || node.P2pPort == 0 {
+		return fmt.Errorf("insufficient parameters of the boot node %s", node.PublicKey)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// 模拟群组合约
+type mockGroupManager struct {
+	mockContract
+	permitted bool
+	groups    map[uint64]*syscontracts.GroupInfo
+	calls     []string
+}
+
+// 执行交易，返回事件的 code 和 msg
+func (m *mockGroupManager) apply(call *packet.DecodedCall) (uint64, string) {
+	if call.Method == "createGroup" {
+		var group syscontracts.GroupInfo
+		_ = json.Unmarshal([]byte(call.Params[0].Value.(string)), &group)
+		if _, ok := m.groups[group.GroupID]; ok {
+			return 1, fmt.Sprintf("the group %d already exists", group.GroupID)
+		}
+		m.groups[group.GroupID] = &group
+		return 0, "success"
+	}
+	id, _ := strconv.ParseUint(fmt.Sprint(call.Params[0].Value), 10, 64)
+	group, ok := m.groups[id]
+	if !ok {
+		return 2, fmt.Sprintf("the group %d does not exist", id)
+	}
+	data := []byte(call.Params[1].Value.(string))
+	switch call.Method {
+	case "addBootNode":
+		var node syscontracts.BootNode
+		_ = json.Unmarshal(data, &node)
+		group.BootNodes = append(group.BootNodes, node)
+	case "delBootNode":
+		var node syscontracts.BootNode
+		_ = json.Unmarshal(data, &node)
+		nodes := group.BootNodes[:0:0]
+		for _, n := range group.BootNodes {
+			if n.PublicKey != node.PublicKey {
+				nodes = append(nodes, n)
+			}
+		}
+		group.BootNodes = nodes
+	case "updateBootNodes":
+		group.BootNodes = nil
+		_ = json.Unmarshal(data, &group.BootNodes)
+	}
+	return 0, "success"
+}
+
+func (m *mockGroupManager) handlers(t *testing.T) map[string]mockHandler {
+	return m.serve(t, func(call *packet.DecodedCall) interface{} {
+		switch call.Method {
+		case "hasGroupOpPermission":
+			permitted := byte(0)
+			if m.permitted {
+				permitted = 1
+			}
+			return hexutil.Encode(common.LeftPadBytes([]byte{permitted}, 32))
+		case "getGroupByID":
+			id, _ := strconv.ParseUint(fmt.Sprint(call.Params[0].Value), 10, 64)
+			return sysResult(m.groups[id])
+		default:
+			groups := make([]*syscontracts.GroupInfo, 0, len(m.groups))
+			for _, group := range m.groups {
+				groups = append(groups, group)
+			}
+			return sysResult(groups)
+		}
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		m.calls = append(m.calls, call.Method)
+		code, msg := m.apply(call)
+		return []*packet.Log{testSysLog(precompile.GroupManagementAddress, "Notify", code, msg)}
+	})
+}
+
+func TestGroupClient(t *testing.T) {
+	m := &mockGroupManager{groups: make(map[uint64]*syscontracts.GroupInfo)}
+	server, url := newMockNode(t, m.handlers(t))
+	defer server.Close()
+	groupClient, err := NewGroupClientWithKey(context.Background(), url, newTestKey(t), 7)
+	assert.NoError(t, err)
+	defer groupClient.RpcClient.Close()
+	ctx := context.Background()
+
+	boot := syscontracts.BootNode{PublicKey: "0x01", ExternalIP: "10.0.0.1", P2pPort: 16791}
+	_, err = groupClient.GroupCreate(ctx, syscontracts.GroupInfo{Name: "group7", BootNodes: []syscontracts.BootNode{boot}})
+	assert.Equal(t, ErrNoGroupPermission, err)
+	assert.Empty(t, m.calls)
+
+	m.permitted = true
+	result, err := groupClient.GroupCreate(ctx, syscontracts.GroupInfo{Name: "group7", BootNodes: []syscontracts.BootNode{boot}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), result.BlockNumber)
+	assert.Equal(t, []SysEvent{{Name: "Notify", Code: 0, Msg: "success"}}, result.Events)
+
+	// 重复创建时事件的 code 不为 0
+	result, err = groupClient.GroupCreate(ctx, syscontracts.GroupInfo{Name: "group7"})
+	assert.Error(t, err)
+	assert.Equal(t, uint64(1), result.Events[0].Code)
+
+	other := syscontracts.BootNode{PublicKey: "0x02", ExternalIP: "10.0.0.2", P2pPort: 16792}
+	_, err = groupClient.GroupAddBootNode(ctx, other)
+	assert.NoError(t, err)
+	_, err = groupClient.GroupDelBootNode(ctx, boot)
+	assert.NoError(t, err)
+	group, err := groupClient.GroupQuery(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "group7", group.Name)
+	assert.Equal(t, []syscontracts.BootNode{other}, group.BootNodes)
+
+	_, err = groupClient.GroupUpdateBootNodes(ctx, []syscontracts.BootNode{boot, other})
+	assert.NoError(t, err)
+	groups, err := groupClient.GroupQueryAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, groups, 1)
+	assert.Len(t, groups[0].BootNodes, 2)
+
+	_, err = groupClient.GroupAddBootNode(ctx, syscontracts.BootNode{PublicKey: "0x03"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"createGroup", "createGroup", "addBootNode", "delBootNode", "updateBootNodes"}, m.calls)
+}
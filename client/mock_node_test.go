@@ -0,0 +1,193 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/types"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+)
+
+type mockHandler func(params []json.RawMessage) interface{}
+
+// 模拟节点，按照方法名分发 json rpc 请求
+func newMockNode(t *testing.T, handlers map[string]mockHandler) (*httptest.Server, URL) {
+	type request struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	handle := func(req request) map[string]interface{} {
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		if handler, ok := handlers[req.Method]; ok {
+			resp["result"] = handler(req.Params)
+		} else {
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found: " + req.Method}
+		}
+		return resp
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request error: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// 批量请求
+		if len(body) > 0 && body[0] == '[' {
+			var reqs []request
+			_ = json.Unmarshal(body, &reqs)
+			resps := make([]map[string]interface{}, len(reqs))
+			for i, req := range reqs {
+				resps[i] = handle(req)
+			}
+			_ = json.NewEncoder(w).Encode(resps)
+			return
+		}
+		var req request
+		_ = json.Unmarshal(body, &req)
+		_ = json.NewEncoder(w).Encode(handle(req))
+	}))
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	p, _ := strconv.ParseUint(port, 10, 64)
+	return server, NewURL(host, p)
+}
+
+func mockParamString(params []json.RawMessage, i int) string {
+	var s string
+	if i < len(params) {
+		_ = json.Unmarshal(params[i], &s)
+	}
+	return s
+}
+
+// mockContract 模拟链上的合约，每笔交易打包在一个新的区块中
+// contents 为空时按照交易的目标地址使用系统合约的接口解码调用
+type mockContract struct {
+	mu       sync.Mutex
+	contents []packet.ContractContent
+	block    uint64
+	logs     []*packet.Log
+	receipts map[string]interface{}
+}
+
+// serve 返回合约的 rpc 处理函数，call 返回 eth_call 的结果，transact 执行交易并返回回执中的日志
+// call 和 transact 都在 mu 的保护下调用，call 为空时不处理 eth_call
+func (m *mockContract) serve(t *testing.T, call func(call *packet.DecodedCall) interface{}, transact func(call *packet.DecodedCall) []*packet.Log) map[string]mockHandler {
+	decode := func(data []byte, to *common.Address) (*packet.DecodedCall, bool) {
+		contents := m.contents
+		if contents == nil && to != nil {
+			contents = packet.PrecompiledContents(to.Hex())
+		}
+		decoded, err := packet.DecodeCallData(data, to, contents...)
+		if err != nil {
+			t.Errorf("decode call data error: %v", err)
+			return nil, false
+		}
+		return decoded, true
+	}
+	handlers := map[string]mockHandler{
+		"eth_sendRawTransaction": func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			raw, _ := hexutil.Decode(mockParamString(params, 0))
+			tx := new(types.Transaction)
+			if err := rlp.DecodeBytes(raw, tx); err != nil {
+				t.Errorf("decode transaction error: %v", err)
+				return nil
+			}
+			decoded, ok := decode(tx.Data(), tx.To())
+			if !ok {
+				return nil
+			}
+			logs := transact(decoded)
+			m.block++
+			number := hexutil.EncodeUint64(m.block)
+			for _, eLog := range logs {
+				eLog.BlockNumber = number
+			}
+			m.logs = append(m.logs, logs...)
+			if logs == nil {
+				logs = []*packet.Log{}
+			}
+			hash := tx.Hash().Hex()
+			if m.receipts == nil {
+				m.receipts = make(map[string]interface{})
+			}
+			m.receipts[hash] = map[string]interface{}{
+				"transactionHash": hash,
+				"blockNumber":     number,
+				"blockHash":       common.BigToHash(new(big.Int).SetUint64(m.block)).Hex(),
+				"status":          "0x1",
+				"logs":            logs,
+			}
+			return hash
+		},
+		"eth_getTransactionReceipt": func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			return m.receipts[mockParamString(params, 0)]
+		},
+		"eth_blockNumber": func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			return hexutil.EncodeUint64(m.block)
+		},
+		"eth_getLogs": func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			var query struct {
+				FromBlock string `json:"fromBlock"`
+				ToBlock   string `json:"toBlock"`
+			}
+			_ = json.Unmarshal(params[0], &query)
+			from, _ := hexutil.DecodeUint64(query.FromBlock)
+			to, err := hexutil.DecodeUint64(query.ToBlock)
+			if err != nil {
+				to = m.block
+			}
+			logs := []*packet.Log{}
+			for _, eLog := range m.logs {
+				if number, _ := hexutil.DecodeUint64(eLog.BlockNumber); number >= from && number <= to {
+					logs = append(logs, eLog)
+				}
+			}
+			return logs
+		},
+	}
+	if call != nil {
+		handlers["eth_call"] = func(params []json.RawMessage) interface{} {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			var tx struct {
+				To   common.Address `json:"to"`
+				Data hexutil.Bytes  `json:"data"`
+			}
+			if err := json.Unmarshal(params[0], &tx); err != nil {
+				t.Errorf("decode call error: %v", err)
+				return nil
+			}
+			decoded, ok := decode(tx.Data, &tx.To)
+			if !ok {
+				return nil
+			}
+			return call(decoded)
+		}
+	}
+	return handlers
+}
+
+// sysResult 系统合约 {code,msg,data} 形式的查询结果
+func sysResult(data interface{}) string {
+	bytes, _ := json.Marshal(map[string]interface{}{"code": 0, "msg": "success", "data": data})
+	return wasmStringResult(string(bytes))
+}
@@ -0,0 +1,388 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+const (
+	NFTMinterAdd = "add"
+	NFTMinterDel = "del"
+)
+
+type NFTClient struct {
+	ContractClient
+}
+
+// NFTTxResult nft 合约交易的结果，Events 为回执中解析出的 nft 合约事件
+type NFTTxResult struct {
+	TxHash      string
+	BlockNumber uint64
+	Events      []SysEvent
+}
+
+// NFTError nft 合约的失败事件，例如 mint_Error、batchTransfer_Error
+type NFTError struct {
+	SysEvent
+}
+
+func (e *NFTError) Error() string {
+	return fmt.Sprintf("nft %s: code %d, %s %s", e.Name, e.Code, e.Msg, e.Detail)
+}
+
+// Err 返回第一个失败事件对应的 *NFTError
+func (result *NFTTxResult) Err() error {
+	for _, event := range result.Events {
+		if isNFTErrorEvent(event) {
+			return &NFTError{event}
+		}
+	}
+	return nil
+}
+
+func isNFTErrorEvent(event SysEvent) bool {
+	return event.Code != 0 || strings.HasSuffix(event.Name, "_Error")
+}
+
+// NFTTransfer 批量转移中的一项
+type NFTTransfer struct {
+	TokenID     string `json:"tokenId"`
+	Price       uint64 `json:"price"`
+	To          string `json:"to"`
+	Description string `json:"description"`
+}
+
+// NFTItemResult 批量操作中每一项的结果，Detail 为该项事件中的信息，mint 成功时为 tokenId
+type NFTItemResult struct {
+	Index  int
+	Detail string
+	Err    error
+}
+
+func NewNFTClient(ctx context.Context, url URL, keyfilePath string, passphrase string) (*NFTClient, error) {
+	client, err := NewContractClient(ctx, url, keyfilePath, passphrase, precompile.NFTContractAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	nftClient := &NFTClient{
+		*client,
+	}
+	return nftClient, nil
+}
+
+// 传入key 构造NFT客户端
+func NewNFTClientWithKey(ctx context.Context, url URL, key *keystore.Key) (*NFTClient, error) {
+	client, err := NewContractClientWithKey(ctx, url, key, precompile.NFTContractAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	nftClient := &NFTClient{
+		*client,
+	}
+	return nftClient, nil
+}
+
+// Mint 发行 nft 给 to，to 为空时发行给当前账户
+func (nftClient NFTClient) Mint(ctx context.Context, to string, token syscontracts.NFTToken) (*NFTTxResult, error) {
+	result, err := nftClient.mint(ctx, to, []syscontracts.NFTToken{token})
+	if err != nil {
+		return result, err
+	}
+	return result, result.Err()
+}
+
+// BatchMint 在一笔交易中发行多个 nft，返回每一项的结果
+// 合约按照数据的顺序为每一项产生 mint 或 mint_Error 事件
+func (nftClient NFTClient) BatchMint(ctx context.Context, to string, tokens []syscontracts.NFTToken) ([]NFTItemResult, *NFTTxResult, error) {
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("no nft to mint")
+	}
+	result, err := nftClient.mint(ctx, to, tokens)
+	if err != nil {
+		return nil, result, err
+	}
+	items, err := nftItemResults(result, len(tokens), "mint", "mint_Error")
+	return items, result, err
+}
+
+func (nftClient NFTClient) mint(ctx context.Context, to string, tokens []syscontracts.NFTToken) (*NFTTxResult, error) {
+	metadata, _ := json.Marshal(map[string]interface{}{"method": "mint", "data": tokens})
+	return nftClient.nftTransact(ctx, "mint", string(metadata), to)
+}
+
+// Transfer 转移 nft，price 为成交价格
+func (nftClient NFTClient) Transfer(ctx context.Context, tokenID string, price uint64, to string, desc string) (*NFTTxResult, error) {
+	result, err := nftClient.nftTransact(ctx, "transfer", tokenID, strconv.FormatUint(price, 10), to, desc)
+	if err != nil {
+		return result, err
+	}
+	return result, result.Err()
+}
+
+// BatchTransfer 在一笔交易中转移多个 nft，返回每一项的结果
+// 合约按照数据的顺序为每一项产生 batchTransfer 或 batchTransfer_Error 事件
+func (nftClient NFTClient) BatchTransfer(ctx context.Context, transfers []NFTTransfer) ([]NFTItemResult, *NFTTxResult, error) {
+	if len(transfers) == 0 {
+		return nil, nil, errors.New("no nft to transfer")
+	}
+	batchData, _ := json.Marshal(map[string]interface{}{"method": "batchTransfer", "data": transfers})
+	result, err := nftClient.nftTransact(ctx, "batchTransfer", string(batchData))
+	if err != nil {
+		return nil, result, err
+	}
+	items, err := nftItemResults(result, len(transfers), "batchTransfer", "batchTransfer_Error")
+	return items, result, err
+}
+
+// nftItemResults 将批量操作的事件按顺序对应到每一项
+func nftItemResults(result *NFTTxResult, n int, okEvent, errEvent string) ([]NFTItemResult, error) {
+	var events []SysEvent
+	for _, event := range result.Events {
+		if event.Name == okEvent || event.Name == errEvent {
+			events = append(events, event)
+		}
+	}
+	if len(events) != n {
+		if err := result.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("the %s transaction %s has %d item events, expect %d", okEvent, result.TxHash, len(events), n)
+	}
+	items := make([]NFTItemResult, 0, n)
+	for i, event := range events {
+		item := NFTItemResult{Index: i, Detail: event.Detail}
+		if isNFTErrorEvent(event) {
+			item.Err = &NFTError{event}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Mortgage 将 nft 抵押给 to
+func (nftClient NFTClient) Mortgage(ctx context.Context, tokenID string, to string, desc string) (*NFTTxResult, error) {
+	return nftClient.nftTransactChecked(ctx, "mortgage", tokenID, to, desc)
+}
+
+// Redeem 赎回抵押的 nft
+func (nftClient NFTClient) Redeem(ctx context.Context, tokenID string) (*NFTTxResult, error) {
+	return nftClient.nftTransactChecked(ctx, "redeem", tokenID)
+}
+
+// ChangeNFTData 修改 nft 的信息，update 为需要修改的字段
+func (nftClient NFTClient) ChangeNFTData(ctx context.Context, tokenID string, update map[string]interface{}) (*NFTTxResult, error) {
+	bytes, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+	return nftClient.nftTransactChecked(ctx, "changeNFTData", tokenID, string(bytes))
+}
+
+// ModifyMinter 添加（NFTMinterAdd）或删除（NFTMinterDel）发行者
+func (nftClient NFTClient) ModifyMinter(ctx context.Context, operation string, address string) (*NFTTxResult, error) {
+	if operation != NFTMinterAdd && operation != NFTMinterDel {
+		return nil, fmt.Errorf("invalid minter operation %s", operation)
+	}
+	return nftClient.nftTransactChecked(ctx, "modifyMinter", operation, address)
+}
+
+func (nftClient NFTClient) nftTransactChecked(ctx context.Context, funcName string, funcParams ...string) (*NFTTxResult, error) {
+	result, err := nftClient.nftTransact(ctx, funcName, funcParams...)
+	if err != nil {
+		return result, err
+	}
+	return result, result.Err()
+}
+
+func (nftClient NFTClient) nftTransact(ctx context.Context, funcName string, funcParams ...string) (*NFTTxResult, error) {
+	receipt, err := nftClient.contractTransact(ctx, funcParams, funcName, precompile.NFTContractAddress)
+	if receipt == nil {
+		return nil, err
+	}
+	result := &NFTTxResult{
+		TxHash:      receipt.TransactionHash,
+		BlockNumber: receipt.Parsing().BlockNumber,
+	}
+	events, parseErr := ParseSysEvents(receipt, nftClient.ContractContent.GetEvents())
+	result.Events = events
+	if err == nil {
+		err = parseErr
+	}
+	return result, err
+}
+
+func (nftClient NFTClient) nftQuery(ctx context.Context, funcName string, funcParams ...string) (interface{}, error) {
+	result, err := nftClient.contractCallWithParams(ctx, funcParams, funcName, precompile.NFTContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]interface{})[0], nil
+}
+
+func (nftClient NFTClient) nftQueryJson(ctx context.Context, v interface{}, funcName string, funcParams ...string) error {
+	raw, err := nftClient.nftQuery(ctx, funcName, funcParams...)
+	if err != nil {
+		return err
+	}
+	if err := ParseSysContractResult(raw.(string), v); err != nil {
+		return fmt.Errorf("%s: %v", funcName, err)
+	}
+	return nil
+}
+
+// GetNFT 查询 nft 的信息
+func (nftClient NFTClient) GetNFT(ctx context.Context, tokenID string) (*syscontracts.NFTToken, error) {
+	var token syscontracts.NFTToken
+	if err := nftClient.nftQueryJson(ctx, &token, "getNFTById", tokenID); err != nil {
+		return nil, err
+	}
+	if token.TokenID == "" {
+		token.TokenID = tokenID
+	}
+	return &token, nil
+}
+
+// Records 查询 nft 的流转记录
+func (nftClient NFTClient) Records(ctx context.Context, tokenID string) ([]syscontracts.NFTRecord, error) {
+	var records []syscontracts.NFTRecord
+	err := nftClient.nftQueryJson(ctx, &records, "showRecordById", tokenID)
+	return records, err
+}
+
+// NFTsByMinter 查询发行者发行的 nft
+func (nftClient NFTClient) NFTsByMinter(ctx context.Context, minter string) ([]syscontracts.NFTToken, error) {
+	var tokens []syscontracts.NFTToken
+	err := nftClient.nftQueryJson(ctx, &tokens, "getNFTByMinter", minter)
+	return tokens, err
+}
+
+// MortgagedNFTs 查询抵押给 holder 的 nft
+func (nftClient NFTClient) MortgagedNFTs(ctx context.Context, holder string) ([]syscontracts.NFTToken, error) {
+	var tokens []syscontracts.NFTToken
+	err := nftClient.nftQueryJson(ctx, &tokens, "getMortgageNFTByHolder", holder)
+	return tokens, err
+}
+
+// OwnerOf 查询 nft 的持有者
+func (nftClient NFTClient) OwnerOf(ctx context.Context, tokenID string) (string, error) {
+	raw, err := nftClient.nftQuery(ctx, "ownerOf", tokenID)
+	if err != nil {
+		return "", err
+	}
+	var owner string
+	if ParseSysContractResult(raw.(string), &owner) == nil && owner != "" {
+		return owner, nil
+	}
+	return raw.(string), nil
+}
+
+// BalanceOf 查询账户持有的 nft 数量
+func (nftClient NFTClient) BalanceOf(ctx context.Context, owner string) (uint64, error) {
+	var balance uint64
+	err := nftClient.nftQueryJson(ctx, &balance, "balanceOf", owner)
+	return balance, err
+}
+
+// TotalSupply 查询 nft 的总量
+func (nftClient NFTClient) TotalSupply(ctx context.Context) (int32, error) {
+	raw, err := nftClient.nftQuery(ctx, "totalSupply")
+	if err != nil {
+		return 0, err
+	}
+	return raw.(int32), nil
+}
+
+// Tokens 按页遍历所有的 nft，元素为 syscontracts.NFTToken
+func (nftClient NFTClient) Tokens(pageSize int) *NFTIterator {
+	return nftClient.newIterator(pageSize, "showTokenByIndex")
+}
+
+// TokensOf 按页遍历账户持有的 nft，元素为 syscontracts.NFTToken
+func (nftClient NFTClient) TokensOf(owner string, pageSize int) *NFTIterator {
+	return nftClient.newIterator(pageSize, "balanceOfDetail", owner)
+}
+
+// Minters 按页遍历所有的发行者
+func (nftClient NFTClient) Minters(pageSize int) *NFTIterator {
+	return nftClient.newIterator(pageSize, "showAllMintersByIndex")
+}
+
+// TokenOwners 按页遍历 nft 和持有者
+func (nftClient NFTClient) TokenOwners(pageSize int) *NFTIterator {
+	return nftClient.newIterator(pageSize, "showTokenOwnerByIndex")
+}
+
+// NFTIterator 按页遍历 nft 合约的分页查询（pagesize/pagenum），pagenum 从 0 开始
+//
+//	it := nftClient.Tokens(100)
+//	for it.Next(ctx) {
+//		var token syscontracts.NFTToken
+//		err := it.Decode(&token)
+//	}
+//	if it.Err() != nil {...}
+type NFTIterator struct {
+	client   NFTClient
+	funcName string
+	params   []string
+	pageSize int
+	pageNum  int
+
+	items []json.RawMessage
+	pos   int
+	last  bool
+	err   error
+}
+
+// pageSize 不大于 0 时使用 100
+func (nftClient NFTClient) newIterator(pageSize int, funcName string, params ...string) *NFTIterator {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	return &NFTIterator{client: nftClient, funcName: funcName, params: params, pageSize: pageSize, pos: -1}
+}
+
+// Next 移动到下一项，没有更多的数据或者出错时返回 false
+func (it *NFTIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos+1 < len(it.items) {
+		it.pos++
+		return true
+	}
+	if it.last {
+		return false
+	}
+
+	params := append(append([]string{}, it.params...), strconv.Itoa(it.pageSize), strconv.Itoa(it.pageNum))
+	var items []json.RawMessage
+	if err := it.client.nftQueryJson(ctx, &items, it.funcName, params...); err != nil {
+		it.err = err
+		return false
+	}
+	it.pageNum++
+	it.last = len(items) < it.pageSize
+	it.items = items
+	it.pos = 0
+	return len(items) > 0
+}
+
+// Decode 将当前项解析到 v 中
+func (it *NFTIterator) Decode(v interface{}) error {
+	if it.pos < 0 || it.pos >= len(it.items) {
+		return errors.New("no current item")
+	}
+	return json.Unmarshal(it.items[it.pos], v)
+}
+
+func (it *NFTIterator) Err() error {
+	return it.err
+}
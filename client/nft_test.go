@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// 模拟 nft 合约，名称为空的 token 发行失败
+type mockNFT struct {
+	mockContract
+	tokens []syscontracts.NFTToken
+	pages  [][2]int
+}
+
+func nftEventLog(name string, code uint64, msg, detail string) *packet.Log {
+	return testSysLog(precompile.NFTContractAddress, name, code, msg, detail)
+}
+
+func (m *mockNFT) apply(call *packet.DecodedCall) []*packet.Log {
+	var logs []*packet.Log
+	var batch struct {
+		Data json.RawMessage `json:"data"`
+	}
+	switch call.Method {
+	case "mint":
+		var tokens []syscontracts.NFTToken
+		_ = json.Unmarshal([]byte(call.Params[0].Value.(string)), &batch)
+		_ = json.Unmarshal(batch.Data, &tokens)
+		for _, token := range tokens {
+			if token.Name == "" {
+				logs = append(logs, nftEventLog("mint_Error", 1, "invalid nft", "name is empty"))
+				continue
+			}
+			token.TokenID = fmt.Sprintf("token%d", len(m.tokens))
+			token.Owner = call.Params[1].Value.(string)
+			m.tokens = append(m.tokens, token)
+			logs = append(logs, nftEventLog("mint", 0, "success", token.TokenID))
+		}
+	case "batchTransfer":
+		var transfers []NFTTransfer
+		_ = json.Unmarshal([]byte(call.Params[0].Value.(string)), &batch)
+		_ = json.Unmarshal(batch.Data, &transfers)
+		for _, transfer := range transfers {
+			found := false
+			for i := range m.tokens {
+				if m.tokens[i].TokenID == transfer.TokenID {
+					m.tokens[i].Owner, m.tokens[i].Price, found = transfer.To, transfer.Price, true
+				}
+			}
+			if found {
+				logs = append(logs, nftEventLog("batchTransfer", 0, "success", transfer.TokenID))
+			} else {
+				logs = append(logs, nftEventLog("batchTransfer_Error", 2, "nft not found", transfer.TokenID))
+			}
+		}
+	}
+	return logs
+}
+
+func (m *mockNFT) handlers(t *testing.T) map[string]mockHandler {
+	return m.serve(t, func(call *packet.DecodedCall) interface{} {
+		switch call.Method {
+		case "getNFTById":
+			for _, token := range m.tokens {
+				if token.TokenID == call.Params[0].Value.(string) {
+					return sysResult(token)
+				}
+			}
+		case "showTokenByIndex":
+			size, _ := strconv.Atoi(fmt.Sprint(call.Params[0].Value))
+			num, _ := strconv.Atoi(fmt.Sprint(call.Params[1].Value))
+			m.pages = append(m.pages, [2]int{size, num})
+			page := []syscontracts.NFTToken{}
+			for i := size * num; i < size*(num+1) && i < len(m.tokens); i++ {
+				page = append(page, m.tokens[i])
+			}
+			return sysResult(page)
+		case "totalSupply":
+			return hexutil.Encode(common.LeftPadBytes([]byte{byte(len(m.tokens))}, 32))
+		}
+		return sysResult(nil)
+	}, m.apply)
+}
+
+func TestNFTClient(t *testing.T) {
+	m := &mockNFT{}
+	server, url := newMockNode(t, m.handlers(t))
+	defer server.Close()
+	nftClient, err := NewNFTClientWithKey(context.Background(), url, newTestKey(t))
+	assert.NoError(t, err)
+	defer nftClient.RpcClient.Close()
+	ctx := context.Background()
+	owner := "0x1000000000000000000000000000000000000001"
+
+	result, err := nftClient.Mint(ctx, owner, syscontracts.NFTToken{Name: "first", Symbol: "F", Price: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, "token0", result.Events[0].Detail)
+
+	_, err = nftClient.Mint(ctx, owner, syscontracts.NFTToken{})
+	var nftErr *NFTError
+	assert.ErrorAs(t, err, &nftErr)
+	assert.Equal(t, "mint_Error", nftErr.Name)
+
+	tokens := []syscontracts.NFTToken{{Name: "a"}, {}, {Name: "b"}, {Name: "c"}}
+	items, _, err := nftClient.BatchMint(ctx, owner, tokens)
+	assert.NoError(t, err)
+	assert.Len(t, items, 4)
+	assert.Equal(t, "token1", items[0].Detail)
+	assert.Error(t, items[1].Err)
+	assert.Equal(t, "token3", items[3].Detail)
+	assert.NoError(t, items[3].Err)
+
+	to := "0x2000000000000000000000000000000000000002"
+	items, _, err = nftClient.BatchTransfer(ctx, []NFTTransfer{{TokenID: "token1", Price: 5, To: to}, {TokenID: "missing", To: to}})
+	assert.NoError(t, err)
+	assert.NoError(t, items[0].Err)
+	assert.ErrorAs(t, items[1].Err, &nftErr)
+	assert.Equal(t, uint64(2), nftErr.Code)
+
+	token, err := nftClient.GetNFT(ctx, "token1")
+	assert.NoError(t, err)
+	assert.Equal(t, to, token.Owner)
+	assert.Equal(t, uint64(5), token.Price)
+
+	total, err := nftClient.TotalSupply(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), total)
+
+	it := nftClient.Tokens(3)
+	var names []string
+	for it.Next(ctx) {
+		var token syscontracts.NFTToken
+		assert.NoError(t, it.Decode(&token))
+		names = append(names, token.Name)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"first", "a", "b", "c"}, names)
+	assert.Equal(t, [][2]int{{3, 0}, {3, 1}}, m.pages)
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+)
+
+const (
+	NodeKeyFileName    = "nodekey"
+	NodePubKeyFileName = "node.pubkey"
+)
+
+// NodeKey 节点的密钥对，算法由 crypto.SetEncryption 指定（secp256k1 或 SM2）
+type NodeKey struct {
+	PrivKey crypto.PrivKey
+	// 节点管理合约中使用的公钥，不带 0x 和 04 前缀
+	PublicKey  string
+	KeyFile    string
+	PubKeyFile string
+}
+
+// GenerateNodeKey 生成节点密钥，dir 不为空时将私钥和公钥分别保存到 dir 下的 nodekey 和 node.pubkey 文件中
+func GenerateNodeKey(dir string) (*NodeKey, error) {
+	privKey, err := crypto.GenerateKeypair()
+	if err != nil {
+		return nil, err
+	}
+	key, err := newNodeKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return key, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	key.KeyFile = filepath.Join(dir, NodeKeyFileName)
+	key.PubKeyFile = filepath.Join(dir, NodePubKeyFileName)
+	if err := crypto.SavePrivKeyToFile(key.KeyFile, privKey); err != nil {
+		return nil, fmt.Errorf("save the node key error: %v", err)
+	}
+	if err := crypto.SavePubKeyToFile(key.PubKeyFile, privKey.GetPubKey()); err != nil {
+		return nil, fmt.Errorf("save the node public key error: %v", err)
+	}
+	return key, nil
+}
+
+// LoadNodeKey 从 nodekey 文件中加载节点密钥
+func LoadNodeKey(keyFile string) (*NodeKey, error) {
+	privKey, err := crypto.LoadPrivKeyFromFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newNodeKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	key.KeyFile = keyFile
+	return key, nil
+}
+
+func newNodeKey(privKey crypto.PrivKey) (*NodeKey, error) {
+	pub, err := privKey.GetPubKey().Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &NodeKey{PrivKey: privKey, PublicKey: enodeID(hex.EncodeToString(pub))}, nil
+}
+
+// NodeRegistration 节点注册信息，Signature 为节点私钥对节点信息的签名，用于证明注册者持有节点私钥
+type NodeRegistration struct {
+	Node      syscontracts.NodeInfo `json:"node"`
+	Signature string                `json:"signature"`
+}
+
+// NewNodeRegistration 使用节点密钥生成签名的注册信息
+// info 中必须包含节点名称和 ip，公钥使用节点密钥的公钥，端口为空时使用默认值
+func NewNodeRegistration(key *NodeKey, info syscontracts.NodeInfo) (*NodeRegistration, error) {
+	if err := packet.ParamValid(info.Name, "name"); err != nil || info.Name == "" {
+		return nil, fmt.Errorf("invalid node name %s", info.Name)
+	}
+	info.PublicKey = key.PublicKey
+	node, err := setNodeInfoDefault(NodeClient{NodeName: info.Name}, info)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign2(key.PrivKey, nodeInfoHash(node))
+	if err != nil {
+		return nil, err
+	}
+	return &NodeRegistration{Node: *node, Signature: hex.EncodeToString(sig)}, nil
+}
+
+func nodeInfoHash(node *syscontracts.NodeInfo) []byte {
+	bytes, _ := json.Marshal(node)
+	return crypto.DefaultHasher.Hash256(bytes)
+}
+
+// Verify 使用节点信息中的公钥验证签名
+func (reg *NodeRegistration) Verify() error {
+	pub, err := hex.DecodeString(reg.Node.PublicKey)
+	if err != nil {
+		return fmt.Errorf("invalid node public key: %v", err)
+	}
+	// 节点管理合约中的公钥不带 04 前缀
+	if len(pub) == 64 {
+		pub = append([]byte{4}, pub...)
+	}
+	sig, err := hex.DecodeString(reg.Signature)
+	if err != nil || len(sig) == 0 {
+		return errors.New("invalid node registration signature")
+	}
+	ok, err := crypto.Verify(pub, nodeInfoHash(&reg.Node), sig)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("the node registration signature does not match the public key")
+	}
+	return nil
+}
+
+// RegisterNode 使用客户端的管理员账户提交节点注册信息，并通过 validJoinNode 校验节点可以加入网络
+func (nodeClient NodeClient) RegisterNode(ctx context.Context, reg *NodeRegistration) (*packet.Receipt, error) {
+	if err := reg.Verify(); err != nil {
+		return nil, err
+	}
+	bytes, _ := json.Marshal(reg.Node)
+	receipt, err := nodeClient.sysContractTransact(ctx, precompile.NodeManagementAddress, "add", string(bytes))
+	if err != nil {
+		return receipt, fmt.Errorf("add the node %s error: %v", reg.Node.Name, err)
+	}
+	valid, err := nodeClient.NodeValidJoin(ctx, reg.Node.PublicKey)
+	if err != nil {
+		return receipt, err
+	}
+	if !valid {
+		return receipt, fmt.Errorf("the node %s is added but not valid to join the network", reg.Node.Name)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeClient_RegisterNode(t *testing.T) {
+	dir := t.TempDir()
+	key, err := GenerateNodeKey(dir)
+	assert.NoError(t, err)
+	assert.Len(t, key.PublicKey, 128)
+	assert.FileExists(t, filepath.Join(dir, NodeKeyFileName))
+	pub, err := crypto.LoadPubKeyFromFile(key.PubKeyFile)
+	assert.NoError(t, err)
+	assert.True(t, pub.Equals(key.PrivKey.GetPubKey()))
+
+	loaded, err := LoadNodeKey(key.KeyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, key.PublicKey, loaded.PublicKey)
+
+	reg, err := NewNodeRegistration(loaded, syscontracts.NodeInfo{Name: "node2", ExternalIP: "10.0.0.2", InternalIP: "127.0.0.2"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(16791), reg.Node.P2pPort)
+	assert.Equal(t, NodeStatusNormal, reg.Node.Status)
+	assert.NoError(t, reg.Verify())
+
+	// 修改注册信息后签名校验失败
+	tampered := *reg
+	tampered.Node.ExternalIP = "10.0.0.3"
+	assert.Error(t, tampered.Verify())
+
+	m := &mockNodeManager{pending: make(map[string]uint32)}
+	server, url := newMockNode(t, m.handlers(t))
+	defer server.Close()
+	nodeClient, err := NewNodeClientWithKey(context.Background(), url, newTestKey(t), "")
+	assert.NoError(t, err)
+	defer nodeClient.RpcClient.Close()
+
+	_, err = nodeClient.RegisterNode(context.Background(), &tampered)
+	assert.Error(t, err)
+	assert.Empty(t, m.nodes)
+
+	receipt, err := nodeClient.RegisterNode(context.Background(), reg)
+	assert.NoError(t, err)
+	assert.NotNil(t, receipt)
+	assert.Equal(t, []syscontracts.NodeInfo{reg.Node}, m.nodes)
+}
@@ -0,0 +1,260 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+)
+
+const (
+	NodeTypeConsensus uint32 = 1
+	NodeTypeObserver  uint32 = 2
+
+	NodeStatusNormal  uint32 = 1
+	NodeStatusDeleted uint32 = 2
+)
+
+// 等待节点类型变更生效时查询共识节点的时间间隔
+var nodePollInterval = 2 * time.Second
+
+// Node 节点管理合约中的节点信息
+type Node struct {
+	syscontracts.NodeInfo
+}
+
+func (node Node) IsConsensus() bool {
+	return node.Type == NodeTypeConsensus
+}
+
+func (node Node) IsObserver() bool {
+	return node.Type != NodeTypeConsensus
+}
+
+func (node Node) IsDeleted() bool {
+	return node.Status == NodeStatusDeleted
+}
+
+// Enode 节点内网地址的 enode url
+func (node Node) Enode() string {
+	return EnodeURL(node.PublicKey, node.InternalIP, node.P2pPort)
+}
+
+// ExternalEnode 节点外网地址的 enode url
+func (node Node) ExternalEnode() string {
+	return EnodeURL(node.PublicKey, node.ExternalIP, node.P2pPort)
+}
+
+// Enode 节点管理合约返回的 enode 信息
+type Enode struct {
+	PublicKey string `json:"publicKey"`
+	IP        string `json:"ip"`
+	Port      uint32 `json:"port"`
+}
+
+func (enode Enode) URL() string {
+	return EnodeURL(enode.PublicKey, enode.IP, enode.Port)
+}
+
+// EnodeURL 生成 enode://<公钥>@<ip>:<端口> 格式的节点地址，公钥可以带 0x 和非压缩公钥的 04 前缀
+func EnodeURL(publicKey string, ip string, p2pPort uint32) string {
+	return fmt.Sprintf("enode://%s@%s", enodeID(publicKey), net.JoinHostPort(ip, strconv.FormatUint(uint64(p2pPort), 10)))
+}
+
+func enodeID(publicKey string) string {
+	id := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(publicKey), "0x"), "0X")
+	if len(id) == 130 && strings.HasPrefix(id, "04") {
+		id = id[2:]
+	}
+	return strings.ToLower(id)
+}
+
+// ParseEnode 解析 enode url，生成添加节点时使用的节点信息，内网和外网地址都使用 url 中的 ip
+func ParseEnode(rawurl string) (syscontracts.NodeInfo, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return syscontracts.NodeInfo{}, err
+	}
+	if u.Scheme != "enode" || u.User == nil {
+		return syscontracts.NodeInfo{}, fmt.Errorf("invalid enode url %s", rawurl)
+	}
+	publicKey := enodeID(u.User.Username())
+	if _, err := hex.DecodeString(publicKey); err != nil || len(publicKey) != 128 {
+		return syscontracts.NodeInfo{}, fmt.Errorf("invalid public key in the enode url %s", rawurl)
+	}
+	port, err := strconv.ParseUint(u.Port(), 10, 32)
+	if err != nil || u.Hostname() == "" {
+		return syscontracts.NodeInfo{}, fmt.Errorf("invalid address in the enode url %s", rawurl)
+	}
+	return syscontracts.NodeInfo{
+		PublicKey:  publicKey,
+		ExternalIP: u.Hostname(),
+		InternalIP: u.Hostname(),
+		P2pPort:    uint32(port),
+	}, nil
+}
+
+// 调用节点管理合约返回字符串的查询方法
+func (nodeClient NodeClient) nodeQuery(ctx context.Context, funcName string, funcParams ...string) (string, error) {
+	result, err := nodeClient.contractCallWithParams(ctx, funcParams, funcName, precompile.NodeManagementAddress)
+	if err != nil {
+		return "", err
+	}
+	res := result.([]interface{})
+	return res[0].(string), nil
+}
+
+func (nodeClient NodeClient) queryNodes(ctx context.Context, funcName string, funcParams ...string) ([]Node, error) {
+	raw, err := nodeClient.nodeQuery(ctx, funcName, funcParams...)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []Node
+	if err := ParseSysContractResult(raw, &nodes); err != nil {
+		return nil, fmt.Errorf("%s: %v", funcName, err)
+	}
+	return nodes, nil
+}
+
+func (nodeClient NodeClient) queryEnodes(ctx context.Context, funcName string) ([]Enode, error) {
+	raw, err := nodeClient.nodeQuery(ctx, funcName)
+	if err != nil {
+		return nil, err
+	}
+	var enodes []Enode
+	if err := ParseSysContractResult(raw, &enodes); err != nil {
+		return nil, fmt.Errorf("%s: %v", funcName, err)
+	}
+	return enodes, nil
+}
+
+// 查询所有的节点
+func (nodeClient NodeClient) Nodes(ctx context.Context) ([]Node, error) {
+	return nodeClient.queryNodes(ctx, "getAllNodes")
+}
+
+// 按照查询条件查询节点，条件中为零值的字段不参与查询
+func (nodeClient NodeClient) NodesBy(ctx context.Context, request syscontracts.NodeQueryInfo) ([]Node, error) {
+	m := make(map[string]interface{})
+	if request.Name != "" {
+		m["name"] = request.Name
+	}
+	if request.Status != 0 {
+		m["status"] = request.Status
+	}
+	if request.Type != 0 {
+		m["type"] = request.Type
+	}
+	bytes, _ := json.Marshal(m)
+	return nodeClient.queryNodes(ctx, "getNodes", string(bytes))
+}
+
+// 查询节点客户端名称对应的节点
+func (nodeClient NodeClient) Node(ctx context.Context) (*Node, error) {
+	if err := packet.ParamValid(nodeClient.NodeName, "name"); err != nil {
+		return nil, err
+	}
+	nodes, err := nodeClient.NodesBy(ctx, syscontracts.NodeQueryInfo{Name: nodeClient.NodeName})
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		if nodes[i].Name == nodeClient.NodeName {
+			return &nodes[i], nil
+		}
+	}
+	return nil, fmt.Errorf("the node %s is not found", nodeClient.NodeName)
+}
+
+// 查询当前参与共识的节点
+func (nodeClient NodeClient) ConsensusNodes(ctx context.Context) ([]Node, error) {
+	return nodeClient.queryNodes(ctx, "getVrfConsensusNodes")
+}
+
+// 查询正常状态节点的 enode 信息
+func (nodeClient NodeClient) NormalEnodes(ctx context.Context) ([]Enode, error) {
+	return nodeClient.queryEnodes(ctx, "getNormalEnodeNodes")
+}
+
+// 查询已删除节点的 enode 信息
+func (nodeClient NodeClient) DeletedEnodes(ctx context.Context) ([]Enode, error) {
+	return nodeClient.queryEnodes(ctx, "getDeletedEnodeNodes")
+}
+
+// 查询公钥对应的节点是否可以加入网络
+func (nodeClient NodeClient) NodeValidJoin(ctx context.Context, publicKey string) (bool, error) {
+	result, err := nodeClient.contractCallWithParams(ctx, []string{strings.TrimSpace(publicKey)}, "validJoinNode", precompile.NodeManagementAddress)
+	if err != nil {
+		return false, err
+	}
+	res := result.([]interface{})
+	return res[0].(int32) == 1, nil
+}
+
+// PromoteToConsensus 将节点设置为共识节点，delayNum 为延迟生效的区块数，0 为立即生效
+// 交易成功后等待节点出现在共识节点列表中，直到 ctx 结束
+func (nodeClient NodeClient) PromoteToConsensus(ctx context.Context, delayNum uint64) (*packet.Receipt, error) {
+	return nodeClient.changeNodeType(ctx, NodeTypeConsensus, delayNum)
+}
+
+// DemoteToObserver 将节点设置为观察者节点，交易成功后等待节点从共识节点列表中移除，直到 ctx 结束
+func (nodeClient NodeClient) DemoteToObserver(ctx context.Context, delayNum uint64) (*packet.Receipt, error) {
+	return nodeClient.changeNodeType(ctx, NodeTypeObserver, delayNum)
+}
+
+func (nodeClient NodeClient) changeNodeType(ctx context.Context, nodeType uint32, delayNum uint64) (*packet.Receipt, error) {
+	node, err := nodeClient.Node(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if node.IsDeleted() {
+		return nil, fmt.Errorf("the node %s has been deleted", node.Name)
+	}
+
+	var receipt *packet.Receipt
+	if node.Type != nodeType {
+		request := syscontracts.NodeUpdateInfo{Desc: node.Desc, Type: nodeType, DelayNum: delayNum}
+		bytes, _ := json.Marshal(request)
+		receipt, err = nodeClient.sysContractTransact(ctx, precompile.NodeManagementAddress, "update", node.Name, string(bytes))
+		if err != nil {
+			return receipt, fmt.Errorf("update the type of the node %s error: %v", node.Name, err)
+		}
+	}
+	return receipt, nodeClient.WaitConsensus(ctx, node.PublicKey, nodeType == NodeTypeConsensus)
+}
+
+// WaitConsensus 等待公钥对应的节点加入（consensus 为 true）或者离开共识节点列表，直到 ctx 结束
+func (nodeClient NodeClient) WaitConsensus(ctx context.Context, publicKey string, consensus bool) error {
+	id := enodeID(publicKey)
+	for {
+		nodes, err := nodeClient.ConsensusNodes(ctx)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, node := range nodes {
+			if enodeID(node.PublicKey) == id {
+				found = true
+				break
+			}
+		}
+		if found == consensus {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("wait for the consensus nodes to change: %v", ctx.Err())
+		case <-time.After(nodePollInterval):
+		}
+	}
+}
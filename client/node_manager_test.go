@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNodePublicKey = "0x04e1a4b6c3d2f0a9876543210fedcba98e1a4b6c3d2f0a9876543210fedcba98e1a4b6c3d2f0a9876543210fedcba98e1a4b6c3d2f0a9876543210fedcba98e1a4"
+
+// 模拟节点管理合约，节点类型的变更在 delay 次共识节点查询之后生效
+type mockNodeManager struct {
+	mockContract
+	nodes   []syscontracts.NodeInfo
+	pending map[string]uint32
+	delay   int
+	updates []syscontracts.NodeUpdateInfo
+}
+
+func (m *mockNodeManager) query(method string, params []interface{}) string {
+	switch method {
+	case "getAllNodes":
+		return sysResult(m.nodes)
+	case "getNodes":
+		var query syscontracts.NodeQueryInfo
+		_ = json.Unmarshal([]byte(params[0].(string)), &query)
+		var nodes []syscontracts.NodeInfo
+		for _, node := range m.nodes {
+			if query.Name == "" || node.Name == query.Name {
+				nodes = append(nodes, node)
+			}
+		}
+		return sysResult(nodes)
+	case "getVrfConsensusNodes":
+		if m.delay > 0 {
+			m.delay--
+		} else {
+			for i, node := range m.nodes {
+				if typ, ok := m.pending[node.Name]; ok {
+					m.nodes[i].Type = typ
+					delete(m.pending, node.Name)
+				}
+			}
+		}
+		var nodes []syscontracts.NodeInfo
+		for _, node := range m.nodes {
+			if node.Type == NodeTypeConsensus {
+				nodes = append(nodes, node)
+			}
+		}
+		return sysResult(nodes)
+	case "getNormalEnodeNodes":
+		return sysResult([]map[string]interface{}{{"PublicKey": m.nodes[0].PublicKey, "IP": m.nodes[0].InternalIP, "Port": m.nodes[0].P2pPort}})
+	}
+	return wasmStringResult("")
+}
+
+func (m *mockNodeManager) handlers(t *testing.T) map[string]mockHandler {
+	return m.serve(t, func(call *packet.DecodedCall) interface{} {
+		if call.Method == "validJoinNode" {
+			valid := byte(0)
+			for _, node := range m.nodes {
+				if call.Params[0].Value.(string) == node.PublicKey && node.Status == NodeStatusNormal {
+					valid = 1
+				}
+			}
+			return hexutil.Encode(common.LeftPadBytes([]byte{valid}, 32))
+		}
+		values := make([]interface{}, 0, len(call.Params))
+		for _, p := range call.Params {
+			values = append(values, p.Value)
+		}
+		return m.query(call.Method, values)
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		switch call.Method {
+		case "add":
+			var node syscontracts.NodeInfo
+			_ = json.Unmarshal([]byte(call.Params[0].Value.(string)), &node)
+			m.nodes = append(m.nodes, node)
+		case "update":
+			var request syscontracts.NodeUpdateInfo
+			_ = json.Unmarshal([]byte(call.Params[1].Value.(string)), &request)
+			m.updates = append(m.updates, request)
+			m.pending[call.Params[0].Value.(string)] = request.Type
+		}
+		return []*packet.Log{testSysLog(precompile.NodeManagementAddress, call.Method, uint64(0), "success")}
+	})
+}
+
+func TestEnodeURL(t *testing.T) {
+	url := EnodeURL(testNodePublicKey, "127.0.0.1", 16791)
+	assert.Equal(t, "enode://"+strings.TrimPrefix(testNodePublicKey, "0x04")+"@127.0.0.1:16791", url)
+
+	info, err := ParseEnode(url)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", info.InternalIP)
+	assert.Equal(t, uint32(16791), info.P2pPort)
+	assert.Equal(t, url, EnodeURL(info.PublicKey, info.ExternalIP, info.P2pPort))
+
+	_, err = ParseEnode("enode://1234@127.0.0.1:16791")
+	assert.Error(t, err)
+}
+
+func TestNodeClient_PromoteAndDemote(t *testing.T) {
+	defer func(interval time.Duration) { nodePollInterval = interval }(nodePollInterval)
+	nodePollInterval = time.Millisecond
+
+	m := &mockNodeManager{
+		nodes: []syscontracts.NodeInfo{{
+			Name: "node1", Desc: "observer", Type: NodeTypeObserver, Status: NodeStatusNormal,
+			ExternalIP: "10.0.0.1", InternalIP: "127.0.0.1", PublicKey: testNodePublicKey, P2pPort: 16791,
+		}},
+		pending: make(map[string]uint32),
+	}
+	server, url := newMockNode(t, m.handlers(t))
+	defer server.Close()
+	nodeClient, err := NewNodeClientWithKey(context.Background(), url, newTestKey(t), "node1")
+	assert.NoError(t, err)
+	defer nodeClient.RpcClient.Close()
+	ctx := context.Background()
+
+	nodes, err := nodeClient.Nodes(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 1)
+	assert.True(t, nodes[0].IsObserver())
+	assert.Equal(t, EnodeURL(testNodePublicKey, "127.0.0.1", 16791), nodes[0].Enode())
+
+	enodes, err := nodeClient.NormalEnodes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, nodes[0].Enode(), enodes[0].URL())
+
+	valid, err := nodeClient.NodeValidJoin(ctx, testNodePublicKey)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	m.delay = 3
+	receipt, err := nodeClient.PromoteToConsensus(ctx, 10)
+	assert.NoError(t, err)
+	assert.NotNil(t, receipt)
+	assert.Equal(t, []syscontracts.NodeUpdateInfo{{Desc: "observer", Type: NodeTypeConsensus, DelayNum: 10}}, m.updates)
+	consensus, err := nodeClient.ConsensusNodes(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, consensus, 1)
+
+	// 类型没有变化时不发送交易
+	receipt, err = nodeClient.PromoteToConsensus(ctx, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+	assert.Len(t, m.updates, 1)
+
+	// 变更一直没有生效时等待到 ctx 结束
+	m.delay = 1 << 30
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = nodeClient.DemoteToObserver(timeout, 0)
+	assert.Error(t, err)
+	assert.Len(t, m.updates, 2)
+}
@@ -0,0 +1,361 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/common"
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/types"
+	vena_common "github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+)
+
+// 离线签名流程：
+// 1. 联网机器通过 BuildUnsignedTx 生成未签名交易，ExportUnsignedTx 导出为 json 文件
+// 2. 离线机器通过 ImportUnsignedTx 导入，人工审核后使用 SignUnsignedTx 签名，ExportSignedTx 导出
+// 3. 联网机器通过 ImportSignedTx 导入，BroadcastSignedTx 广播到链上
+// 每一步都会对交易信封进行校验
+
+// UnsignedTx 未签名的交易信封
+type UnsignedTx struct {
+	From     string         `json:"from"`
+	To       string         `json:"to,omitempty"` // 为空时表示部署合约
+	Data     string         `json:"data"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	Gas      string         `json:"gas,omitempty"`
+	GasPrice string         `json:"gasPrice,omitempty"`
+	Value    string         `json:"value,omitempty"`
+	ChainID  string         `json:"chainId"`
+	Call     *TxCall        `json:"call,omitempty"`
+}
+
+// TxCall 交易调用的合约方法，用于人工审核，签名前会根据它重新生成 data 进行比对
+type TxCall struct {
+	// 合约地址或 cns 名字
+	Contract string `json:"contract"`
+	// 合约类型, wasm 或 evm
+	VmType string   `json:"vmType"`
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	// 调用方法的 abi
+	Abi *packet.FuncDesc `json:"abi"`
+}
+
+// SignedTx 已签名的交易信封
+type SignedTx struct {
+	UnsignedTx
+	RawTx string `json:"rawTx"`
+	Hash  string `json:"hash"`
+}
+
+// 获取链的 chain id
+func (client Client) GetChainID(ctx context.Context) (string, error) {
+	result, err := client.RpcClient.CallContext(ctx, types.NetVersion)
+	if err != nil {
+		return "", err
+	}
+	var chainID string
+	if err = json.Unmarshal(result, &chainID); err != nil {
+		return "", err
+	}
+	return chainID, nil
+}
+
+// 联网生成未签名的交易，contractContent 为空时按照预编译合约的地址获取合约内容
+func (client Client) BuildUnsignedTx(ctx context.Context, executeContract ExecuteContract, contractContent packet.ContractContent, account string) (*UnsignedTx, error) {
+	var err error
+	if contractContent == nil {
+		contractContent, err = GenContractContent(executeContract.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+	txparam, methodAbi, err := getTxparamWithContent(executeContract, contractContent, account)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := client.GetChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vmType := "wasm"
+	if executeContract.Type == "evm" {
+		vmType = "evm"
+	}
+	utx := &UnsignedTx{
+		From:     txparam.From.Hex(),
+		Data:     txparam.Data,
+		Nonce:    hexutil.Uint64(common.NewNonce()),
+		Gas:      txparam.Gas,
+		GasPrice: txparam.GasPrice,
+		Value:    txparam.Value,
+		ChainID:  chainID,
+		Call: &TxCall{
+			Contract: executeContract.Address,
+			VmType:   vmType,
+			Method:   methodAbi.Name,
+			Params:   executeContract.FuncParams,
+			Abi:      methodAbi,
+		},
+	}
+	if txparam.To != nil {
+		utx.To = txparam.To.Hex()
+	}
+	if err = utx.Validate(); err != nil {
+		return nil, err
+	}
+	return utx, nil
+}
+
+// 校验未签名交易信封的格式，并根据 Call 重新生成 data 检查是否与信封一致
+func (utx *UnsignedTx) Validate() error {
+	if !packet.IsMatch(utx.From, "address") {
+		return fmt.Errorf("invalid from address: %s", utx.From)
+	}
+	if utx.To != "" && !packet.IsMatch(utx.To, "address") {
+		return fmt.Errorf("invalid to address: %s", utx.To)
+	}
+	if _, err := hexutil.Decode(utx.Data); err != nil {
+		return fmt.Errorf("invalid data: %v", err)
+	}
+	if err := checkHexQuantity("gas", utx.Gas); err != nil {
+		return err
+	}
+	if err := checkHexQuantity("gasPrice", utx.GasPrice); err != nil {
+		return err
+	}
+	if err := checkHexQuantity("value", utx.Value); err != nil {
+		return err
+	}
+	if utx.ChainID == "" {
+		return errors.New("the chain id of the transaction is empty")
+	}
+	if utx.Call == nil {
+		return nil
+	}
+	return utx.Call.check(utx)
+}
+
+// 根据调用方法的 abi 和参数重新生成交易，与信封中的 to 和 data 比对
+func (call *TxCall) check(utx *UnsignedTx) error {
+	if call.Abi == nil {
+		return errors.New("the abi of the call is empty")
+	}
+	if !strings.EqualFold(call.Abi.Name, call.Method) {
+		return fmt.Errorf("the call method %s does not match the abi %s", call.Method, call.Abi.Name)
+	}
+	executeContract := NewExecuteContract(call.Contract, call.VmType, call.Method, call.Params)
+	txparam, _, err := getTxparamWithContent(*executeContract, packet.ContractContent{call.Abi}, utx.From)
+	if err != nil {
+		return err
+	}
+	if txparam.To == nil || !strings.EqualFold(txparam.To.Hex(), utx.To) {
+		return fmt.Errorf("the to address %s does not match the call contract %s", utx.To, call.Contract)
+	}
+	if !strings.EqualFold(txparam.Data, utx.Data) {
+		return errors.New("the data of the transaction does not match the call")
+	}
+	return nil
+}
+
+// 信封中为空的数量与签名时一样按照 0 处理
+func hexQuantity(value string) *big.Int {
+	n, err := hexutil.DecodeBig(value)
+	if err != nil {
+		return new(big.Int)
+	}
+	return n
+}
+
+func checkHexQuantity(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := hexutil.DecodeBig(value); err != nil {
+		return fmt.Errorf("invalid %s %s: %v", name, value, err)
+	}
+	return nil
+}
+
+func (utx *UnsignedTx) toTxParams() *common.TxParams {
+	txparam := &common.TxParams{
+		From:     vena_common.HexToAddress(utx.From),
+		Gas:      utx.Gas,
+		GasPrice: utx.GasPrice,
+		Value:    utx.Value,
+		Data:     utx.Data,
+		Nonce:    utx.Nonce.String(),
+	}
+	if utx.To != "" {
+		to := vena_common.HexToAddress(utx.To)
+		txparam.To = &to
+	}
+	return txparam
+}
+
+// 离线签名，key 的地址必须与交易的 from 一致
+func SignUnsignedTx(utx *UnsignedTx, key *keystore.Key) (*SignedTx, error) {
+	if err := utx.Validate(); err != nil {
+		return nil, err
+	}
+	if key == nil || key.PrivateKey == nil {
+		return nil, errors.New("the private key is empty")
+	}
+	if !strings.EqualFold(key.Address.Hex(), utx.From) {
+		return nil, fmt.Errorf("the key address %s does not match the from address %s", key.Address.Hex(), utx.From)
+	}
+	tx, err := utx.toTxParams().SignTx(key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTx{
+		UnsignedTx: *utx,
+		RawTx:      hexutil.Encode(raw),
+		Hash:       tx.Hash().Hex(),
+	}, nil
+}
+
+// 校验已签名交易，确认 rawTx 与信封内容一致且签名者为 from
+func (stx *SignedTx) Validate() error {
+	if err := stx.UnsignedTx.Validate(); err != nil {
+		return err
+	}
+	raw, err := hexutil.Decode(stx.RawTx)
+	if err != nil {
+		return fmt.Errorf("invalid raw transaction: %v", err)
+	}
+	tx := new(types.Transaction)
+	if err = rlp.DecodeBytes(raw, tx); err != nil {
+		return fmt.Errorf("decode raw transaction error: %v", err)
+	}
+	if !strings.EqualFold(tx.Hash().Hex(), stx.Hash) {
+		return fmt.Errorf("the transaction hash %s does not match %s", tx.Hash().Hex(), stx.Hash)
+	}
+	if tx.Nonce() != uint64(stx.Nonce) {
+		return errors.New("the nonce of the raw transaction does not match the envelope")
+	}
+	if tx.Gas() != hexQuantity(stx.Gas).Uint64() {
+		return errors.New("the gas of the raw transaction does not match the envelope")
+	}
+	if tx.GasPrice().Cmp(hexQuantity(stx.GasPrice)) != 0 {
+		return errors.New("the gas price of the raw transaction does not match the envelope")
+	}
+	if tx.Value().Cmp(hexQuantity(stx.Value)) != 0 {
+		return errors.New("the value of the raw transaction does not match the envelope")
+	}
+	if hexutil.Encode(tx.Data()) != strings.ToLower(stx.Data) {
+		return errors.New("the data of the raw transaction does not match the envelope")
+	}
+	to := ""
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+	if !strings.EqualFold(to, stx.To) {
+		return errors.New("the to address of the raw transaction does not match the envelope")
+	}
+	// EIP155 签名的交易中包含 chain id，必须与信封一致
+	if tx.Protected() && tx.ChainId().String() != stx.ChainID {
+		return fmt.Errorf("the chain id %s of the raw transaction does not match %s", tx.ChainId().String(), stx.ChainID)
+	}
+	sender, err := types.Sender(types.MakeSigner(tx), tx)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(sender.Hex(), stx.From) {
+		return fmt.Errorf("the signer %s does not match the from address %s", sender.Hex(), stx.From)
+	}
+	return nil
+}
+
+// 广播离线签名的交易，返回交易 hash
+func (client Client) BroadcastSignedTx(ctx context.Context, stx *SignedTx) (string, error) {
+	if err := stx.Validate(); err != nil {
+		return "", err
+	}
+	chainID, err := client.GetChainID(ctx)
+	if err != nil {
+		return "", err
+	}
+	if chainID != stx.ChainID {
+		return "", fmt.Errorf("the chain id %s does not match the node chain id %s", stx.ChainID, chainID)
+	}
+	result, err := client.RpcClient.CallContext(ctx, types.SendRawTransaction, stx.RawTx)
+	if err != nil {
+		return "", err
+	}
+	var txHash string
+	if err = json.Unmarshal(result, &txHash); err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(txHash, stx.Hash) {
+		return "", fmt.Errorf("the returned transaction hash %s does not match %s", txHash, stx.Hash)
+	}
+	return txHash, nil
+}
+
+// 导出未签名交易到文件
+func ExportUnsignedTx(utx *UnsignedTx, filePath string) error {
+	if err := utx.Validate(); err != nil {
+		return err
+	}
+	return exportEnvelope(utx, filePath)
+}
+
+// 从文件导入未签名交易
+func ImportUnsignedTx(filePath string) (*UnsignedTx, error) {
+	var utx UnsignedTx
+	if err := importEnvelope(filePath, &utx); err != nil {
+		return nil, err
+	}
+	if err := utx.Validate(); err != nil {
+		return nil, err
+	}
+	return &utx, nil
+}
+
+// 导出已签名交易到文件
+func ExportSignedTx(stx *SignedTx, filePath string) error {
+	if err := stx.Validate(); err != nil {
+		return err
+	}
+	return exportEnvelope(stx, filePath)
+}
+
+// 从文件导入已签名交易
+func ImportSignedTx(filePath string) (*SignedTx, error) {
+	var stx SignedTx
+	if err := importEnvelope(filePath, &stx); err != nil {
+		return nil, err
+	}
+	if err := stx.Validate(); err != nil {
+		return nil, err
+	}
+	return &stx, nil
+}
+
+func exportEnvelope(envelope interface{}, filePath string) error {
+	envelopeBytes, err := json.MarshalIndent(envelope, "", "\t")
+	if err != nil {
+		return err
+	}
+	return packet.WriteFile(envelopeBytes, filePath)
+}
+
+func importEnvelope(filePath string, envelope interface{}) error {
+	envelopeBytes, err := packet.ParseFileToBytes(filePath)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(envelopeBytes, envelope)
+}
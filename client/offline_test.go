@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/types"
+	vena_common "github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPrivateKey = "b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291"
+
+func newTestKey(t *testing.T) *keystore.Key {
+	priv, err := crypto.HexToECDSA(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &keystore.Key{
+		Address:    crypto.PubkeyToAddress(priv.PublicKey),
+		PrivateKey: priv,
+	}
+}
+
+// 不联网构造未签名交易
+func newTestUnsignedTx(t *testing.T, key *keystore.Key) *UnsignedTx {
+	executeContract := NewExecuteContract(precompile.EvidenceManagementAddress, "wasm", "saveEvidence", []string{"key", "value"})
+	contractContent, err := GenContractContent(precompile.EvidenceManagementAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txparam, methodAbi, err := getTxparamWithContent(*executeContract, contractContent, key.Address.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &UnsignedTx{
+		From:    txparam.From.Hex(),
+		To:      txparam.To.Hex(),
+		Data:    txparam.Data,
+		Nonce:   10,
+		ChainID: "300",
+		Call: &TxCall{
+			Contract: executeContract.Address,
+			VmType:   "wasm",
+			Method:   methodAbi.Name,
+			Params:   executeContract.FuncParams,
+			Abi:      methodAbi,
+		},
+	}
+}
+
+func TestOfflineSignAndValidate(t *testing.T) {
+	key := newTestKey(t)
+	utx := newTestUnsignedTx(t, key)
+	assert.NoError(t, utx.Validate())
+
+	stx, err := SignUnsignedTx(utx, key)
+	assert.NoError(t, err)
+	assert.NoError(t, stx.Validate())
+
+	// 篡改参数后 data 与 call 不一致
+	tampered := *utx
+	call := *utx.Call
+	call.Params = []string{"key", "other"}
+	tampered.Call = &call
+	assert.Error(t, tampered.Validate())
+
+	// 篡改 nonce 后与 rawTx 不一致
+	tamperedSigned := *stx
+	tamperedSigned.Nonce = 11
+	assert.Error(t, tamperedSigned.Validate())
+
+	// 替换为 value 和 gas price 不同的 rawTx
+	for _, modify := range []func(*UnsignedTx){
+		func(u *UnsignedTx) { u.Value = "0x64" },
+		func(u *UnsignedTx) { u.GasPrice = "0x1" },
+		func(u *UnsignedTx) { u.Gas = "0x5208" },
+	} {
+		modified := *utx
+		modify(&modified)
+		modifiedSigned, err := SignUnsignedTx(&modified, key)
+		assert.NoError(t, err)
+		tamperedSigned = *stx
+		tamperedSigned.RawTx, tamperedSigned.Hash = modifiedSigned.RawTx, modifiedSigned.Hash
+		assert.Error(t, tamperedSigned.Validate())
+		assert.NoError(t, modifiedSigned.Validate())
+	}
+
+	// 使用其他私钥签名
+	other, err := crypto.HexToECDSA("289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032")
+	assert.NoError(t, err)
+	_, err = SignUnsignedTx(utx, &keystore.Key{Address: crypto.PubkeyToAddress(other.PublicKey), PrivateKey: other})
+	assert.Error(t, err)
+}
+
+// 使用 EIP155 签名的交易按照 rawTx 中的 chain id 恢复签名者，chain id 必须与信封一致
+func TestSignedTxValidateEIP155(t *testing.T) {
+	key := newTestKey(t)
+	utx := newTestUnsignedTx(t, key)
+	data, err := hexutil.Decode(utx.Data)
+	assert.NoError(t, err)
+	sign := func(chainID int64) *SignedTx {
+		tx := types.NewTransaction(uint64(utx.Nonce), vena_common.HexToAddress(utx.To), big.NewInt(0), 0, big.NewInt(0), data)
+		tx, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(chainID)), key.PrivateKey)
+		assert.NoError(t, err)
+		raw, err := rlp.EncodeToBytes(tx)
+		assert.NoError(t, err)
+		return &SignedTx{UnsignedTx: *utx, RawTx: hexutil.Encode(raw), Hash: tx.Hash().Hex()}
+	}
+
+	assert.NoError(t, sign(300).Validate())
+	err = sign(301).Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "chain id 301")
+}
+
+func TestOfflineExportImport(t *testing.T) {
+	key := newTestKey(t)
+	dir, err := ioutil.TempDir("", "offline")
+	assert.NoError(t, err)
+
+	unsignedPath := filepath.Join(dir, "unsigned.json")
+	assert.NoError(t, ExportUnsignedTx(newTestUnsignedTx(t, key), unsignedPath))
+	utx, err := ImportUnsignedTx(unsignedPath)
+	assert.NoError(t, err)
+
+	stx, err := SignUnsignedTx(utx, key)
+	assert.NoError(t, err)
+	signedPath := filepath.Join(dir, "signed.json")
+	assert.NoError(t, ExportSignedTx(stx, signedPath))
+	imported, err := ImportSignedTx(signedPath)
+	assert.NoError(t, err)
+	assert.Equal(t, stx.RawTx, imported.RawTx)
+	assert.Equal(t, stx.Hash, imported.Hash)
+}
+
+func TestBroadcastSignedTx(t *testing.T) {
+	var txHash string
+	server, url := newMockNode(t, map[string]mockHandler{
+		"net_version": func(params []json.RawMessage) interface{} {
+			return "300"
+		},
+		"eth_sendRawTransaction": func(params []json.RawMessage) interface{} {
+			raw, _ := hexutil.Decode(mockParamString(params, 0))
+			txHash = crypto.Keccak256Hash(raw).Hex()
+			return txHash
+		},
+	})
+	defer server.Close()
+
+	key := newTestKey(t)
+	client, err := NewClientWithKey(context.Background(), url, key)
+	assert.NoError(t, err)
+	defer client.RpcClient.Close()
+
+	stx, err := SignUnsignedTx(newTestUnsignedTx(t, key), key)
+	assert.NoError(t, err)
+	hash, err := client.BroadcastSignedTx(context.Background(), stx)
+	assert.NoError(t, err)
+	assert.Equal(t, stx.Hash, hash)
+	assert.Equal(t, txHash, hash)
+
+	stx.ChainID = "301"
+	_, err = client.BroadcastSignedTx(context.Background(), stx)
+	assert.Error(t, err)
+}
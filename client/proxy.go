@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+// ProxyClient 跨链代理合约客户端，每个客户端对应一条链
+type ProxyClient struct {
+	ContractClient
+}
+
+// XAProof 业务合约调用在代理合约中执行后的凭证，Msg 来自回执中的 execute 事件，执行失败时 XAExecute 返回错误
+type XAProof struct {
+	XAID        string `json:"xaID"`
+	ChannelID   string `json:"channelID,omitempty"`
+	Index       int    `json:"index"`
+	Contract    string `json:"contract"`
+	TxHash      string `json:"txHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	Msg         string `json:"msg"`
+}
+
+func NewProxyClient(ctx context.Context, url URL, keyfilePath string, passphrase string) (*ProxyClient, error) {
+	client, err := NewContractClient(ctx, url, keyfilePath, passphrase, precompile.ContractProxyAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	proxyClient := &ProxyClient{
+		*client,
+	}
+	return proxyClient, nil
+}
+
+// 传入key 构造Proxy客户端
+func NewProxyClientWithKey(ctx context.Context, url URL, key *keystore.Key) (*ProxyClient, error) {
+	client, err := NewContractClientWithKey(ctx, url, key, precompile.ContractProxyAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	proxyClient := &ProxyClient{
+		*client,
+	}
+	return proxyClient, nil
+}
+
+// XAExecute 通过代理合约执行跨链事务 crossID 中的第 index 个业务调用，返回 execute 事件中的凭证
+func (proxyClient ProxyClient) XAExecute(ctx context.Context, crossID string, index int, total int, contract string, callData string) (*XAProof, error) {
+	receipt, err := proxyClient.sysContractTransact(ctx, precompile.ContractProxyAddress, "execute",
+		crossID, strconv.Itoa(index), strconv.Itoa(total), contract, callData)
+	if err != nil {
+		return nil, fmt.Errorf("execute %s[%d] error: %w", crossID, index, err)
+	}
+	proof := &XAProof{
+		XAID:        crossID,
+		Index:       index,
+		Contract:    contract,
+		TxHash:      receipt.TransactionHash,
+		BlockNumber: receipt.Parsing().BlockNumber,
+	}
+	events, err := ParseSysEvents(receipt, proxyClient.ContractContent.GetEvents())
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.Name == "execute" {
+			proof.Msg = event.Msg
+			return proof, nil
+		}
+	}
+	return nil, fmt.Errorf("execute %s[%d] error: the execute event is not found in the receipt", crossID, index)
+}
+
+// XARollback 使用 rollbackCallData 回滚跨链事务 crossID 中第 index 个业务调用
+func (proxyClient ProxyClient) XARollback(ctx context.Context, crossID string, index int, contract string, rollbackCallData string) (*packet.Receipt, error) {
+	receipt, err := proxyClient.sysContractTransact(ctx, precompile.ContractProxyAddress, "rollback",
+		crossID, strconv.Itoa(index), contract, rollbackCallData)
+	if err != nil {
+		return receipt, fmt.Errorf("rollback %s[%d] error: %v", crossID, index, err)
+	}
+	return receipt, nil
+}
+
+// XACommit 提交跨链事务 crossID 在当前链上的执行结果
+func (proxyClient ProxyClient) XACommit(ctx context.Context, crossID string) (*packet.Receipt, error) {
+	receipt, err := proxyClient.sysContractTransact(ctx, precompile.ContractProxyAddress, "commit", crossID)
+	if err != nil {
+		return receipt, fmt.Errorf("commit %s error: %v", crossID, err)
+	}
+	return receipt, nil
+}
+
+// SaveProof 将跨链凭证保存到代理合约
+func (proxyClient ProxyClient) SaveProof(ctx context.Context, proof string) (*packet.Receipt, error) {
+	return proxyClient.sysContractTransact(ctx, precompile.ContractProxyAddress, "saveProof", proof)
+}
+
+// GetProof 查询代理合约中保存的跨链凭证
+func (proxyClient ProxyClient) GetProof(ctx context.Context, crossID string) (string, error) {
+	result, err := proxyClient.contractCallWithParams(ctx, []string{crossID}, "getProof", precompile.ContractProxyAddress)
+	if err != nil {
+		return "", err
+	}
+	res := result.([]interface{})
+	return res[0].(string), nil
+}
@@ -0,0 +1,289 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+var (
+	ErrThresholdExceedsSigners = errors.New("the signature threshold exceeds the number of granted signers")
+	ErrNotEnoughSignatures     = errors.New("the proof does not have enough signatures")
+)
+
+// ProxyConfig 代理合约的授权中继地址和签名门限
+// 代理合约没有提供查询接口，配置为通过 ProxyAdminClient 设置的结果，可以保存到文件中
+type ProxyConfig struct {
+	Signers   []string `json:"signers"`
+	Threshold int      `json:"threshold"`
+}
+
+// LoadProxyConfig 从 json 文件中加载代理合约配置，文件不存在时返回空配置
+func LoadProxyConfig(path string) (*ProxyConfig, error) {
+	config := new(ProxyConfig)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bytes, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Save 将代理合约配置保存为 json 文件
+func (config *ProxyConfig) Save(path string) error {
+	bytes, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bytes, 0600)
+}
+
+// IsSigner 判断地址是否是授权的中继地址
+func (config *ProxyConfig) IsSigner(address string) bool {
+	for _, signer := range config.Signers {
+		if common.HexToAddress(signer) == common.HexToAddress(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate 检查签名门限不超过授权地址的数量
+func (config *ProxyConfig) Validate() error {
+	if config.Threshold < 0 {
+		return fmt.Errorf("invalid signature threshold %d", config.Threshold)
+	}
+	if config.Threshold > len(config.Signers) {
+		return fmt.Errorf("%w: threshold %d, signers %d", ErrThresholdExceedsSigners, config.Threshold, len(config.Signers))
+	}
+	return nil
+}
+
+// ProxyAdminClient 代理合约的管理客户端，管理授权的中继地址和跨链凭证的签名门限
+type ProxyAdminClient struct {
+	ProxyClient
+	mu     *sync.Mutex
+	config *ProxyConfig
+}
+
+func NewProxyAdminClient(ctx context.Context, url URL, keyfilePath string, passphrase string, config *ProxyConfig) (*ProxyAdminClient, error) {
+	client, err := NewProxyClient(ctx, url, keyfilePath, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return newProxyAdminClient(client, config)
+}
+
+// 传入key 构造ProxyAdmin客户端
+func NewProxyAdminClientWithKey(ctx context.Context, url URL, key *keystore.Key, config *ProxyConfig) (*ProxyAdminClient, error) {
+	client, err := NewProxyClientWithKey(ctx, url, key)
+	if err != nil {
+		return nil, err
+	}
+	return newProxyAdminClient(client, config)
+}
+
+// config 为已知的代理合约配置，为空时表示还没有授权任何地址
+func newProxyAdminClient(client *ProxyClient, config *ProxyConfig) (*ProxyAdminClient, error) {
+	if config == nil {
+		config = new(ProxyConfig)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &ProxyAdminClient{ProxyClient: *client, mu: new(sync.Mutex), config: config}, nil
+}
+
+// Config 当前的代理合约配置
+func (proxyAdminClient ProxyAdminClient) Config() ProxyConfig {
+	proxyAdminClient.mu.Lock()
+	defer proxyAdminClient.mu.Unlock()
+	config := *proxyAdminClient.config
+	config.Signers = append([]string(nil), config.Signers...)
+	return config
+}
+
+// Grant 授权中继地址
+func (proxyAdminClient ProxyAdminClient) Grant(ctx context.Context, address string) (*packet.Receipt, error) {
+	if !packet.IsMatch(address, "address") {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	proxyAdminClient.mu.Lock()
+	defer proxyAdminClient.mu.Unlock()
+	receipt, err := proxyAdminClient.sysContractTransact(ctx, precompile.ContractProxyAddress, "grant", address)
+	if err != nil {
+		return receipt, fmt.Errorf("grant %s error: %v", address, err)
+	}
+	if !proxyAdminClient.config.IsSigner(address) {
+		proxyAdminClient.config.Signers = append(proxyAdminClient.config.Signers, common.HexToAddress(address).Hex())
+	}
+	return receipt, nil
+}
+
+// Revoke 取消中继地址的授权，取消后授权地址的数量不能小于签名门限
+func (proxyAdminClient ProxyAdminClient) Revoke(ctx context.Context, address string) (*packet.Receipt, error) {
+	if !packet.IsMatch(address, "address") {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	proxyAdminClient.mu.Lock()
+	defer proxyAdminClient.mu.Unlock()
+	config := proxyAdminClient.config
+	var signers []string
+	for _, signer := range config.Signers {
+		if common.HexToAddress(signer) != common.HexToAddress(address) {
+			signers = append(signers, signer)
+		}
+	}
+	if err := (&ProxyConfig{Signers: signers, Threshold: config.Threshold}).Validate(); err != nil {
+		return nil, fmt.Errorf("revoke %s error: %w", address, err)
+	}
+	receipt, err := proxyAdminClient.sysContractTransact(ctx, precompile.ContractProxyAddress, "revoke", address)
+	if err != nil {
+		return receipt, fmt.Errorf("revoke %s error: %v", address, err)
+	}
+	config.Signers = signers
+	return receipt, nil
+}
+
+// SetSignatureThreshold 设置跨链凭证需要的签名数量，不能超过授权地址的数量
+func (proxyAdminClient ProxyAdminClient) SetSignatureThreshold(ctx context.Context, threshold int) (*packet.Receipt, error) {
+	proxyAdminClient.mu.Lock()
+	defer proxyAdminClient.mu.Unlock()
+	if threshold <= 0 {
+		return nil, fmt.Errorf("invalid signature threshold %d", threshold)
+	}
+	if err := (&ProxyConfig{Signers: proxyAdminClient.config.Signers, Threshold: threshold}).Validate(); err != nil {
+		return nil, err
+	}
+	receipt, err := proxyAdminClient.sysContractTransact(ctx, precompile.ContractProxyAddress, "setSignatureThreshold", strconv.Itoa(threshold))
+	if err != nil {
+		return receipt, fmt.Errorf("set the signature threshold error: %v", err)
+	}
+	proxyAdminClient.config.Threshold = threshold
+	return receipt, nil
+}
+
+// ProofSignature 中继对跨链凭证的签名
+type ProofSignature struct {
+	Signer    string `json:"signer"`
+	Signature string `json:"signature"`
+}
+
+// SignedProof 收集到足够签名的跨链凭证，以 json 的形式通过 saveProof 保存
+type SignedProof struct {
+	Proof      *XAProof         `json:"proof"`
+	Signatures []ProofSignature `json:"signatures"`
+}
+
+func xaProofHash(proof *XAProof) []byte {
+	bytes, _ := json.Marshal(proof)
+	return crypto.Keccak256(bytes)
+}
+
+// SignXAProof 使用中继账户的私钥对跨链凭证签名
+func SignXAProof(key *keystore.Key, proof *XAProof) (*ProofSignature, error) {
+	sig, err := crypto.Sign(xaProofHash(proof), key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ProofSignature{Signer: key.Address.Hex(), Signature: hexutil.Encode(sig)}, nil
+}
+
+// Verify 检查签名是否由 Signer 对 proof 签署
+func (sig *ProofSignature) Verify(proof *XAProof) error {
+	bytes, err := hexutil.Decode(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature of %s: %v", sig.Signer, err)
+	}
+	pub, err := crypto.SigToPub(xaProofHash(proof), bytes)
+	if err != nil {
+		return fmt.Errorf("invalid signature of %s: %v", sig.Signer, err)
+	}
+	if crypto.PubkeyToAddress(*pub) != common.HexToAddress(sig.Signer) {
+		return fmt.Errorf("the signature is not signed by %s", sig.Signer)
+	}
+	return nil
+}
+
+// ProofCollector 收集授权中继对同一个跨链凭证的签名
+type ProofCollector struct {
+	proof  *XAProof
+	config ProxyConfig
+	mu     sync.Mutex
+	sigs   map[common.Address]ProofSignature
+}
+
+// NewProofCollector 按照代理合约配置收集签名，只接受授权地址的签名
+func (proxyAdminClient ProxyAdminClient) NewProofCollector(proof *XAProof) *ProofCollector {
+	return &ProofCollector{proof: proof, config: proxyAdminClient.Config(), sigs: make(map[common.Address]ProofSignature)}
+}
+
+// Add 验证并添加签名，同一个地址的签名只保留一个
+func (collector *ProofCollector) Add(sig ProofSignature) error {
+	if !collector.config.IsSigner(sig.Signer) {
+		return fmt.Errorf("%s is not a granted signer", sig.Signer)
+	}
+	if err := sig.Verify(collector.proof); err != nil {
+		return err
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.sigs[common.HexToAddress(sig.Signer)] = sig
+	return nil
+}
+
+// Ready 签名数量是否达到门限
+func (collector *ProofCollector) Ready() bool {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	return len(collector.sigs) >= collector.config.Threshold
+}
+
+// Aggregate 将凭证和签名聚合为 SignedProof，签名按照地址排序
+func (collector *ProofCollector) Aggregate() (*SignedProof, error) {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	if collector.config.Threshold == 0 || len(collector.sigs) < collector.config.Threshold {
+		return nil, fmt.Errorf("%w: got %d, threshold %d", ErrNotEnoughSignatures, len(collector.sigs), collector.config.Threshold)
+	}
+	signed := &SignedProof{Proof: collector.proof}
+	for _, sig := range collector.sigs {
+		signed.Signatures = append(signed.Signatures, sig)
+	}
+	sort.Slice(signed.Signatures, func(i, j int) bool {
+		return strings.ToLower(signed.Signatures[i].Signer) < strings.ToLower(signed.Signatures[j].Signer)
+	})
+	return signed, nil
+}
+
+// SaveSignedProof 聚合收集到的签名，并将签名后的凭证保存到代理合约
+func (proxyAdminClient ProxyAdminClient) SaveSignedProof(ctx context.Context, collector *ProofCollector) (*packet.Receipt, error) {
+	signed, err := collector.Aggregate()
+	if err != nil {
+		return nil, err
+	}
+	bytes, _ := json.Marshal(signed)
+	receipt, err := proxyAdminClient.SaveProof(ctx, string(bytes))
+	if err != nil {
+		return receipt, fmt.Errorf("save the proof of %s error: %v", signed.Proof.XAID, err)
+	}
+	return receipt, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyAdminClient(t *testing.T) {
+	m := &mockProxy{}
+	server, url := newMockNode(t, m.handlers(t))
+	defer server.Close()
+	admin, err := NewProxyAdminClientWithKey(context.Background(), url, newTestKey(t), nil)
+	assert.NoError(t, err)
+	defer admin.RpcClient.Close()
+	ctx := context.Background()
+
+	var keys []*keystore.Key
+	for i := 0; i < 3; i++ {
+		priv, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+		assert.NoError(t, err)
+		keys = append(keys, &keystore.Key{Address: crypto.PubkeyToAddress(priv.PublicKey), PrivateKey: priv})
+	}
+
+	_, err = admin.SetSignatureThreshold(ctx, 1)
+	assert.ErrorIs(t, err, ErrThresholdExceedsSigners)
+	for _, key := range keys[:2] {
+		_, err = admin.Grant(ctx, key.Address.Hex())
+		assert.NoError(t, err)
+	}
+	_, err = admin.SetSignatureThreshold(ctx, 3)
+	assert.ErrorIs(t, err, ErrThresholdExceedsSigners)
+	_, err = admin.SetSignatureThreshold(ctx, 2)
+	assert.NoError(t, err)
+	_, err = admin.Revoke(ctx, keys[0].Address.Hex())
+	assert.ErrorIs(t, err, ErrThresholdExceedsSigners)
+	assert.Equal(t, ProxyConfig{Signers: []string{keys[0].Address.Hex(), keys[1].Address.Hex()}, Threshold: 2}, admin.Config())
+	assert.Equal(t, []string{"grant:" + keys[0].Address.Hex(), "grant:" + keys[1].Address.Hex(), "setSignatureThreshold:2"}, m.others)
+
+	path := filepath.Join(t.TempDir(), "proxy.json")
+	config := admin.Config()
+	assert.NoError(t, config.Save(path))
+	loaded, err := LoadProxyConfig(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &config, loaded)
+
+	proof := &XAProof{XAID: "xa01", ChannelID: "001", Index: 0, Contract: testBusinessContract, TxHash: "0x01", Msg: "ok"}
+	collector := admin.NewProofCollector(proof)
+	sig, err := SignXAProof(keys[0], proof)
+	assert.NoError(t, err)
+	assert.NoError(t, collector.Add(*sig))
+	assert.False(t, collector.Ready())
+	_, err = admin.SaveSignedProof(ctx, collector)
+	assert.ErrorIs(t, err, ErrNotEnoughSignatures)
+
+	// 未授权地址的签名和伪造的签名被拒绝
+	sig, _ = SignXAProof(keys[2], proof)
+	assert.Error(t, collector.Add(*sig))
+	sig.Signer = keys[1].Address.Hex()
+	assert.Error(t, collector.Add(*sig))
+
+	sig, _ = SignXAProof(keys[1], proof)
+	assert.NoError(t, collector.Add(*sig))
+	assert.True(t, collector.Ready())
+	_, err = admin.SaveSignedProof(ctx, collector)
+	assert.NoError(t, err)
+
+	var signed SignedProof
+	assert.NoError(t, json.Unmarshal([]byte(m.others[len(m.others)-1][len("saveProof:"):]), &signed))
+	assert.Equal(t, proof, signed.Proof)
+	assert.Len(t, signed.Signatures, 2)
+	for _, s := range signed.Signatures {
+		assert.NoError(t, s.Verify(proof))
+	}
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	bpcrypto "github.com/Venachain/client-sdk-go/crypto"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+// 范围证明的默认参数：聚合 2 个 16 位的数值
+const (
+	DefaultRangeProofBits    = 16
+	DefaultRangeProofAggSize = 2
+)
+
+var ErrInvalidRangeProof = errors.New("the range proof is invalid")
+
+// RangeProofClient 范围证明合约客户端，Bits 为每个数值的位数，AggSize 为一个证明中聚合的数值个数，两者都必须是 2 的幂
+type RangeProofClient struct {
+	ContractClient
+	Bits    int64
+	AggSize int64
+}
+
+// RangeProofReceipt 提交范围证明的交易结果，Code 和 Msg 来自 Notify 事件
+type RangeProofReceipt struct {
+	PID         string `json:"pid"`
+	TxHash      string `json:"txHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	Code        uint64 `json:"code"`
+	Msg         string `json:"msg"`
+}
+
+// RangeProofResult getResult 查询到的验证结果
+type RangeProofResult struct {
+	PID      string `json:"pid"`
+	Verified bool   `json:"verified"`
+	Raw      string `json:"raw"`
+}
+
+func NewRangeProofClient(ctx context.Context, url URL, keyfilePath string, passphrase string) (*RangeProofClient, error) {
+	client, err := NewContractClient(ctx, url, keyfilePath, passphrase, precompile.BulletProofAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	rangeProofClient := &RangeProofClient{
+		*client,
+		DefaultRangeProofBits,
+		DefaultRangeProofAggSize,
+	}
+	return rangeProofClient, nil
+}
+
+// 传入key 构造RangeProof客户端
+func NewRangeProofClientWithKey(ctx context.Context, url URL, key *keystore.Key) (*RangeProofClient, error) {
+	client, err := NewContractClientWithKey(ctx, url, key, precompile.BulletProofAddress, "wasm")
+	if err != nil {
+		return nil, err
+	}
+	rangeProofClient := &RangeProofClient{
+		*client,
+		DefaultRangeProofBits,
+		DefaultRangeProofAggSize,
+	}
+	return rangeProofClient, nil
+}
+
+func isPowerOfTwo(n int64) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+func (rangeProofClient RangeProofClient) checkParams() error {
+	if !isPowerOfTwo(rangeProofClient.Bits) || rangeProofClient.Bits > 64 {
+		return fmt.Errorf("invalid range proof bits %d", rangeProofClient.Bits)
+	}
+	if !isPowerOfTwo(rangeProofClient.AggSize) {
+		return fmt.Errorf("invalid range proof aggregation size %d", rangeProofClient.AggSize)
+	}
+	return nil
+}
+
+// Prove 为 values 生成范围 scope 下的聚合范围证明，values 的个数必须等于 AggSize，每个值都在 [0, 2^Bits) 中
+func (rangeProofClient RangeProofClient) Prove(values []*big.Int, scope string) (string, error) {
+	if err := rangeProofClient.checkParams(); err != nil {
+		return "", err
+	}
+	if int64(len(values)) != rangeProofClient.AggSize {
+		return "", fmt.Errorf("got %d values, expect %d", len(values), rangeProofClient.AggSize)
+	}
+	for _, v := range values {
+		if v == nil || v.Sign() < 0 || v.BitLen() > int(rangeProofClient.Bits) {
+			return "", fmt.Errorf("the value %v is out of the %d bits range", v, rangeProofClient.Bits)
+		}
+	}
+	return bpcrypto.GetRangeProof(values, scope, rangeProofClient.AggSize, rangeProofClient.Bits)
+}
+
+// VerifyLocal 在本地验证范围证明，证明无效时返回 ErrInvalidRangeProof
+func (rangeProofClient RangeProofClient) VerifyLocal(proof string, scope string) error {
+	if err := rangeProofClient.checkParams(); err != nil {
+		return err
+	}
+	ok, err := bpcrypto.VerifyRangeProof(proof, scope, rangeProofClient.AggSize, rangeProofClient.Bits)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRangeProof, err)
+	}
+	if !ok {
+		return ErrInvalidRangeProof
+	}
+	return nil
+}
+
+// SubmitProof 在本地验证通过后提交范围证明，等待交易回执并解析 Notify 事件
+// 链上验证失败时同时返回 Code 不为 0 的结果和 *SysEventError
+func (rangeProofClient RangeProofClient) SubmitProof(ctx context.Context, userID string, proof string, pid string, scope string) (*RangeProofReceipt, error) {
+	if err := rangeProofClient.VerifyLocal(proof, scope); err != nil {
+		return nil, err
+	}
+	receipt, err := rangeProofClient.contractTransact(ctx, []string{userID, proof, pid, scope}, "verifyProofByRange", precompile.BulletProofAddress)
+	if err != nil {
+		return nil, fmt.Errorf("verify the range proof %s error: %v", pid, err)
+	}
+	events, err := ParseSysEvents(receipt, rangeProofClient.ContractContent.GetEvents())
+	if err != nil {
+		return nil, fmt.Errorf("verify the range proof %s error: %v", pid, err)
+	}
+	for _, event := range events {
+		if event.Name != "Notify" {
+			continue
+		}
+		result := &RangeProofReceipt{
+			PID:         pid,
+			TxHash:      receipt.TransactionHash,
+			BlockNumber: receipt.Parsing().BlockNumber,
+			Code:        event.Code,
+			Msg:         event.Msg,
+		}
+		if event.Code != 0 {
+			return result, &SysEventError{event}
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("verify the range proof %s error: the Notify event is not found in the receipt", pid)
+}
+
+// ProveAndSubmit 生成范围证明并提交
+func (rangeProofClient RangeProofClient) ProveAndSubmit(ctx context.Context, userID string, pid string, scope string, values []*big.Int) (*RangeProofReceipt, error) {
+	proof, err := rangeProofClient.Prove(values, scope)
+	if err != nil {
+		return nil, err
+	}
+	return rangeProofClient.SubmitProof(ctx, userID, proof, pid, scope)
+}
+
+// GetResult 查询 pid 对应的范围证明的验证结果
+func (rangeProofClient RangeProofClient) GetResult(ctx context.Context, pid string) (*RangeProofResult, error) {
+	result, err := rangeProofClient.contractCallWithParams(ctx, []string{pid}, "getResult", precompile.BulletProofAddress)
+	if err != nil {
+		return nil, err
+	}
+	res := result.([]interface{})
+	raw := strings.TrimSpace(res[0].(string))
+	if raw == "" {
+		return nil, fmt.Errorf("the range proof %s is not found", pid)
+	}
+	verified, err := parseRangeProofResult(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse the result of range proof %s error: %v", pid, err)
+	}
+	return &RangeProofResult{PID: pid, Verified: verified, Raw: raw}, nil
+}
+
+// 验证结果可能是 true/false、0/1 或者 {code,msg,data} 形式的 json
+func parseRangeProofResult(raw string) (bool, error) {
+	if strings.HasPrefix(raw, "{") {
+		var data interface{}
+		if err := ParseSysContractResult(raw, &data); err != nil {
+			return false, err
+		}
+		if data == nil {
+			return true, nil
+		}
+		raw = strings.Trim(fmt.Sprint(data), "\"")
+	}
+	switch strings.ToLower(raw) {
+	case "success", "pass", "passed":
+		return true, nil
+	case "fail", "failed":
+		return false, nil
+	}
+	if verified, err := strconv.ParseBool(raw); err == nil {
+		return verified, nil
+	}
+	return false, fmt.Errorf("unknown result %s", raw)
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	bpcrypto "github.com/Venachain/client-sdk-go/crypto"
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/stretchr/testify/assert"
+)
+
+// 模拟范围证明合约，使用 m 和 n 验证提交的证明
+type mockRangeProof struct {
+	mockContract
+	m, n    int64
+	results map[string]bool
+}
+
+func (mock *mockRangeProof) handlers(t *testing.T) map[string]mockHandler {
+	return mock.serve(t, func(call *packet.DecodedCall) interface{} {
+		verified, ok := mock.results[call.Params[0].Value.(string)]
+		if !ok {
+			return wasmStringResult("")
+		}
+		if verified {
+			return wasmStringResult("true")
+		}
+		return wasmStringResult("false")
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		proof, pid, scope := call.Params[1].Value.(string), call.Params[2].Value.(string), call.Params[3].Value.(string)
+		ok, _ := bpcrypto.VerifyRangeProof(proof, scope, mock.m, mock.n)
+		mock.results[pid] = ok
+		code, msg := uint64(0), "success"
+		if !ok {
+			code, msg = 1, "verify failed"
+		}
+		return []*packet.Log{testSysLog(precompile.BulletProofAddress, "Notify", code, msg)}
+	})
+}
+
+func TestRangeProofClient(t *testing.T) {
+	mock := &mockRangeProof{m: 1, n: 8, results: make(map[string]bool)}
+	server, url := newMockNode(t, mock.handlers(t))
+	defer server.Close()
+	rangeProofClient, err := NewRangeProofClientWithKey(context.Background(), url, newTestKey(t))
+	assert.NoError(t, err)
+	defer rangeProofClient.RpcClient.Close()
+	rangeProofClient.Bits, rangeProofClient.AggSize = 8, 1
+	ctx := context.Background()
+	scope := "0_255"
+
+	_, err = rangeProofClient.Prove([]*big.Int{big.NewInt(256)}, scope)
+	assert.Error(t, err)
+	_, err = rangeProofClient.Prove([]*big.Int{big.NewInt(1), big.NewInt(2)}, scope)
+	assert.Error(t, err)
+
+	proof, err := rangeProofClient.Prove([]*big.Int{big.NewInt(200)}, scope)
+	assert.NoError(t, err)
+	assert.NoError(t, rangeProofClient.VerifyLocal(proof, scope))
+
+	// 范围不同的证明在本地验证失败，不会提交
+	_, err = rangeProofClient.SubmitProof(ctx, "user1", proof, "p1", "0_100")
+	assert.ErrorIs(t, err, ErrInvalidRangeProof)
+	assert.Empty(t, mock.receipts)
+
+	result, err := rangeProofClient.SubmitProof(ctx, "user1", proof, "p1", scope)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), result.Code)
+	assert.NotEmpty(t, result.TxHash)
+
+	// 链上验证失败时返回带有 code 的结果
+	mock.mu.Lock()
+	mock.n = 4
+	mock.mu.Unlock()
+	result, err = rangeProofClient.SubmitProof(ctx, "user1", proof, "p3", scope)
+	var eventErr *SysEventError
+	assert.ErrorAs(t, err, &eventErr)
+	assert.Equal(t, uint64(1), result.Code)
+	assert.Equal(t, "verify failed", result.Msg)
+	mock.mu.Lock()
+	mock.n = 8
+	mock.mu.Unlock()
+
+	verified, err := rangeProofClient.GetResult(ctx, "p1")
+	assert.NoError(t, err)
+	assert.True(t, verified.Verified)
+	_, err = rangeProofClient.GetResult(ctx, "p2")
+	assert.Error(t, err)
+
+	_, err = rangeProofClient.ProveAndSubmit(ctx, "user1", "p2", scope, []*big.Int{big.NewInt(7)})
+	assert.NoError(t, err)
+}
+
+func TestParseRangeProofResult(t *testing.T) {
+	for raw, expect := range map[string]bool{
+		"true":                                true,
+		"0":                                   false,
+		`{"code":0,"msg":"ok","data":true}`:   true,
+		`{"code":0,"msg":"ok","data":"fail"}`: false,
+	} {
+		verified, err := parseRangeProofResult(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, expect, verified, raw)
+	}
+	_, err := parseRangeProofResult(`{"code":1,"msg":"not found"}`)
+	assert.Error(t, err)
+}
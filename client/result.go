@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+)
+
+// SysContractResult 系统合约查询接口返回的 json 结构
+type SysContractResult struct {
+	Code *int            `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+// ParseSysContractResult 解析系统合约查询接口返回的 json，code 不为 0 时返回错误，data 解析到 v 中
+// 不带 code 的返回值直接解析到 v 中
+func ParseSysContractResult(raw string, v interface{}) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return errors.New("empty result")
+	}
+
+	data := json.RawMessage(raw)
+	if strings.HasPrefix(raw, "{") {
+		var result SysContractResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return err
+		}
+		if result.Code != nil {
+			if *result.Code != 0 {
+				return fmt.Errorf("system contract returned code %d: %s", *result.Code, result.Msg)
+			}
+			data = result.Data
+		}
+	}
+	if len(data) == 0 || string(data) == "null" || v == nil {
+		return nil
+	}
+
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+	// data 可能是 json 编码后的字符串
+	var str string
+	if json.Unmarshal(data, &str) != nil {
+		return err
+	}
+	if str == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(str), v)
+}
+
+// SysEvent 系统合约的事件，参数为 (code, msg)，部分合约的事件还带有 detail 参数
+type SysEvent struct {
+	Name   string
+	Code   uint64
+	Msg    string
+	Detail string
+}
+
+// SysEventError 系统合约事件的 code 不为 0 时的错误
+type SysEventError struct {
+	SysEvent
+}
+
+func (e *SysEventError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("%s returned code %d: %s (%s)", e.Name, e.Code, e.Msg, e.Detail)
+	}
+	return fmt.Sprintf("%s returned code %d: %s", e.Name, e.Code, e.Msg)
+}
+
+// ParseSysEvents 按照合约 abi 中的事件解析回执中系统合约的事件，参数按照 abi 声明的类型解析
+// 事件的 topic 匹配但数据无法解析时返回错误，避免把失败的调用当作成功
+func ParseSysEvents(receipt *packet.Receipt, events []*packet.FuncDesc) ([]SysEvent, error) {
+	descs := make(map[string]*packet.FuncDesc)
+	for _, event := range events {
+		if len(event.Inputs) >= 2 {
+			descs[strings.ToLower(packet.WasmEventTopic(event.Name))] = event
+		}
+	}
+	var result []SysEvent
+	for _, eLog := range receipt.Logs {
+		if len(eLog.Topics) == 0 {
+			continue
+		}
+		desc, ok := descs[strings.ToLower(eLog.Topics[0])]
+		if !ok {
+			continue
+		}
+		types := make([]string, len(desc.Inputs))
+		for i, input := range desc.Inputs {
+			types[i] = input.Type
+		}
+		values, err := packet.WasmEventData(eLog, types)
+		if err != nil {
+			return result, fmt.Errorf("decode the event %s error: %v", desc.Name, err)
+		}
+		code, ok := sysEventCode(values[0])
+		if !ok {
+			return result, fmt.Errorf("the code of the event %s is %s, not an integer", desc.Name, types[0])
+		}
+		event := SysEvent{Name: desc.Name, Code: code, Msg: fmt.Sprint(values[1])}
+		if len(values) > 2 {
+			event.Detail = fmt.Sprint(values[2])
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+// sysEventCode 系统合约事件的 code 声明为 uint32、int32 或 uint64，解析后统一为 uint64
+func sysEventCode(value interface{}) (uint64, bool) {
+	switch code := value.(type) {
+	case uint8:
+		return uint64(code), true
+	case uint16:
+		return uint64(code), true
+	case uint32:
+		return uint64(code), true
+	case uint64:
+		return code, true
+	default:
+		return 0, false
+	}
+}
+
+// cns 事件的参数类型为 (uint64 code, string msg)
+var sysEventTypes = []string{"uint64", "string"}
+
+// sysContractTransact 调用系统合约的写方法，回执中的系统合约事件 code 不为 0 时返回 *SysEventError
+func (contractClient ContractClient) sysContractTransact(ctx context.Context, contract string, funcName string, funcParams ...string) (*packet.Receipt, error) {
+	receipt, err := contractClient.contractTransact(ctx, funcParams, funcName, contract)
+	if err != nil {
+		return receipt, err
+	}
+	var events []*packet.FuncDesc
+	if contractClient.ContractContent != nil {
+		events = contractClient.ContractContent.GetEvents()
+	}
+	sysEvents, err := ParseSysEvents(receipt, events)
+	if err != nil {
+		return receipt, err
+	}
+	for _, event := range sysEvents {
+		if event.Code != 0 {
+			return receipt, &SysEventError{event}
+		}
+	}
+	return receipt, nil
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSysLog 构造系统合约 address 发出的事件日志
+func testSysLog(address, event string, values ...interface{}) *packet.Log {
+	data, _ := rlp.EncodeToBytes(values)
+	return &packet.Log{Address: address, Topics: []string{packet.WasmEventTopic(event)}, Data: hexutil.Encode(data)}
+}
+
+func TestParseSysEvents(t *testing.T) {
+	var events []*packet.FuncDesc
+	for _, address := range []string{precompile.UserManagementAddress, precompile.ContractProxyAddress} {
+		abiBytes, err := precompile.GetContractByte(precompile.List[address])
+		assert.NoError(t, err)
+		content, err := packet.ParseAbiFromJson(abiBytes)
+		assert.NoError(t, err)
+		events = append(events, content.GetEvents()...)
+	}
+
+	// 用户管理合约的 code 为 uint32，代理合约的 code 为 int32
+	receipt := &packet.Receipt{Logs: packet.RecptLogs{
+		testSysLog(precompile.UserManagementAddress, "addUser", uint32(0), "success"),
+		testSysLog(precompile.ContractProxyAddress, "execute", uint32(3), "verify failed"),
+		testSysLog(precompile.UserManagementAddress, "Transfer", uint64(0), "ok"),
+	}}
+	sysEvents, err := ParseSysEvents(receipt, events)
+	assert.NoError(t, err)
+	assert.Equal(t, []SysEvent{
+		{Name: "addUser", Msg: "success"},
+		{Name: "execute", Code: 3, Msg: "verify failed"},
+	}, sysEvents)
+
+	// topic 匹配但数据无法解析时不能当作成功
+	receipt.Logs = append(receipt.Logs, testSysLog(precompile.UserManagementAddress, "addUser", "bad"))
+	_, err = ParseSysEvents(receipt, events)
+	assert.Error(t, err)
+}
@@ -0,0 +1,424 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+)
+
+// Role 用户管理合约中的角色
+type Role string
+
+const (
+	RoleSuperAdmin       Role = "SUPER_ADMIN"
+	RoleChainAdmin       Role = "CHAIN_ADMIN"
+	RoleGroupAdmin       Role = "GROUP_ADMIN"
+	RoleNodeAdmin        Role = "NODE_ADMIN"
+	RoleContractAdmin    Role = "CONTRACT_ADMIN"
+	RoleContractDeployer Role = "CONTRACT_DEPLOYER"
+)
+
+// AllRoles 所有的角色，按照权限从高到低排列
+var AllRoles = []Role{RoleSuperAdmin, RoleChainAdmin, RoleGroupAdmin, RoleNodeAdmin, RoleContractAdmin, RoleContractDeployer}
+
+// 合约中授予和撤销角色的方法名为 add<X>By<Address|Name> 和 del<X>By<Address|Name>
+var roleFuncNames = map[Role]string{
+	RoleChainAdmin:       "ChainAdmin",
+	RoleGroupAdmin:       "GroupAdmin",
+	RoleNodeAdmin:        "NodeAdmin",
+	RoleContractAdmin:    "ContractAdmin",
+	RoleContractDeployer: "ContractDeployer",
+}
+
+// ParseRole 解析角色名，忽略大小写和下划线，例如 CHAIN_ADMIN、chainAdmin
+func ParseRole(s string) (Role, error) {
+	key := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), "_", ""))
+	for _, role := range AllRoles {
+		if strings.ReplaceAll(string(role), "_", "") == key {
+			return role, nil
+		}
+	}
+	return "", fmt.Errorf("unknown role %s", s)
+}
+
+func (role Role) String() string {
+	return string(role)
+}
+
+// roleRank 角色在 AllRoles 中的位置，用于排序
+func roleRank(role Role) int {
+	for i, r := range AllRoles {
+		if r == role {
+			return i
+		}
+	}
+	return len(AllRoles)
+}
+
+func sortRoles(roles []Role) {
+	sort.Slice(roles, func(i, j int) bool {
+		return roleRank(roles[i]) < roleRank(roles[j])
+	})
+}
+
+func parseRoles(names []string) ([]Role, error) {
+	roles := make([]Role, 0, len(names))
+	for _, name := range names {
+		role, err := ParseRole(name)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	sortRoles(roles)
+	return roles, nil
+}
+
+// 超级管理员只能通过 SetSuperAdmin 和 TransferSuperAdmin 设置，合约中也没有按用户名转移超级管理员的方法
+func roleFuncName(op string, role Role, by string) (string, error) {
+	name, ok := roleFuncNames[role]
+	if !ok {
+		return "", fmt.Errorf("the role %s can not be granted or revoked", role)
+	}
+	return op + name + "By" + by, nil
+}
+
+func (roleClient RoleClient) roleTransact(ctx context.Context, op string, role Role, by string, target string) (*packet.Receipt, error) {
+	funcName, err := roleFuncName(op, role, by)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := roleClient.sysContractTransact(ctx, precompile.UserManagementAddress, funcName, target)
+	if err != nil {
+		return receipt, fmt.Errorf("%s error: %v", funcName, err)
+	}
+	return receipt, nil
+}
+
+// GrantRole 为账户地址授予角色
+func (roleClient RoleClient) GrantRole(ctx context.Context, address string, role Role) (*packet.Receipt, error) {
+	if !packet.IsMatch(address, "address") {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	return roleClient.roleTransact(ctx, "add", role, "Address", address)
+}
+
+// RevokeRole 撤销账户地址的角色
+func (roleClient RoleClient) RevokeRole(ctx context.Context, address string, role Role) (*packet.Receipt, error) {
+	if !packet.IsMatch(address, "address") {
+		return nil, fmt.Errorf("invalid address %s", address)
+	}
+	return roleClient.roleTransact(ctx, "del", role, "Address", address)
+}
+
+// GrantRoleByName 为用户名对应的用户授予角色
+func (roleClient RoleClient) GrantRoleByName(ctx context.Context, name string, role Role) (*packet.Receipt, error) {
+	return roleClient.roleTransact(ctx, "add", role, "Name", name)
+}
+
+// RevokeRoleByName 撤销用户名对应的用户的角色
+func (roleClient RoleClient) RevokeRoleByName(ctx context.Context, name string, role Role) (*packet.Receipt, error) {
+	return roleClient.roleTransact(ctx, "del", role, "Name", name)
+}
+
+func (roleClient RoleClient) userQuery(ctx context.Context, v interface{}, funcName string, funcParams ...string) error {
+	result, err := roleClient.contractCallWithParams(ctx, funcParams, funcName, precompile.UserManagementAddress)
+	if err != nil {
+		return err
+	}
+	res := result.([]interface{})
+	raw, _ := res[0].(string)
+	if err := ParseSysContractResult(raw, v); err != nil {
+		return fmt.Errorf("%s error: %v", funcName, err)
+	}
+	return nil
+}
+
+// RolesOf 查询账户地址拥有的角色
+func (roleClient RoleClient) RolesOf(ctx context.Context, address string) ([]Role, error) {
+	var names []string
+	if err := roleClient.userQuery(ctx, &names, "getRolesByAddress", address); err != nil {
+		return nil, err
+	}
+	return parseRoles(names)
+}
+
+// RolesByName 查询用户名对应的用户拥有的角色
+func (roleClient RoleClient) RolesByName(ctx context.Context, name string) ([]Role, error) {
+	var names []string
+	if err := roleClient.userQuery(ctx, &names, "getRolesByName", name); err != nil {
+		return nil, err
+	}
+	return parseRoles(names)
+}
+
+// AddressesOf 查询拥有角色的账户地址
+func (roleClient RoleClient) AddressesOf(ctx context.Context, role Role) ([]string, error) {
+	var addresses []string
+	if err := roleClient.userQuery(ctx, &addresses, "getAddrListOfRole", string(role)); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// CheckRole 检查账户地址是否拥有角色
+func (roleClient RoleClient) CheckRole(ctx context.Context, address string, role Role) (bool, error) {
+	res, err := roleClient.HasRole(ctx, address, string(role))
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
+
+// GetAllUsers 查询所有注册的用户
+func (roleClient RoleClient) GetAllUsers(ctx context.Context) ([]syscontracts.UserInfo, error) {
+	var users []syscontracts.UserInfo
+	if err := roleClient.userQuery(ctx, &users, "getAllUsers"); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Users 遍历所有注册的用户，合约不支持分页，第一次调用 Next 时查询全部用户
+//
+//	it := roleClient.Users()
+//	for it.Next(ctx) {
+//		user := it.User()
+//	}
+//	if it.Err() != nil {...}
+func (roleClient RoleClient) Users() *UserIterator {
+	return &UserIterator{client: roleClient, pos: -1}
+}
+
+// UserIterator 用户遍历器
+type UserIterator struct {
+	client RoleClient
+	users  []syscontracts.UserInfo
+	loaded bool
+	pos    int
+	err    error
+}
+
+// Next 移动到下一个用户，没有更多的用户或者出错时返回 false
+func (it *UserIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if !it.loaded {
+		it.users, it.err = it.client.GetAllUsers(ctx)
+		it.loaded = true
+		if it.err != nil {
+			return false
+		}
+	}
+	if it.pos+1 >= len(it.users) {
+		return false
+	}
+	it.pos++
+	return true
+}
+
+// User 当前的用户
+func (it *UserIterator) User() syscontracts.UserInfo {
+	if it.pos < 0 || it.pos >= len(it.users) {
+		return syscontracts.UserInfo{}
+	}
+	return it.users[it.pos]
+}
+
+func (it *UserIterator) Err() error {
+	return it.err
+}
+
+// RoleMatrixEntry 账户及其拥有的角色，Name 为空表示账户没有注册为用户
+type RoleMatrixEntry struct {
+	Address string `json:"address"`
+	Name    string `json:"name,omitempty"`
+	Roles   []Role `json:"roles"`
+}
+
+// RoleMatrix 某个时刻所有用户和角色的快照，按照地址排序
+type RoleMatrix struct {
+	Entries []RoleMatrixEntry `json:"entries"`
+}
+
+// RoleMatrix 查询所有的用户和拥有角色的账户，生成角色矩阵
+// 拥有角色但没有注册为用户的账户（例如超级管理员）也会出现在矩阵中
+func (roleClient RoleClient) RoleMatrix(ctx context.Context) (*RoleMatrix, error) {
+	entries := make(map[common.Address]*RoleMatrixEntry)
+	entry := func(address string) *RoleMatrixEntry {
+		addr := common.HexToAddress(address)
+		if e, ok := entries[addr]; ok {
+			return e
+		}
+		e := &RoleMatrixEntry{Address: addr.Hex(), Roles: []Role{}}
+		entries[addr] = e
+		return e
+	}
+	users, err := roleClient.GetAllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		entry(user.Address.Hex()).Name = user.Name
+	}
+	for _, role := range AllRoles {
+		addresses, err := roleClient.AddressesOf(ctx, role)
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range addresses {
+			e := entry(address)
+			e.Roles = append(e.Roles, role)
+		}
+	}
+	matrix := &RoleMatrix{}
+	for _, e := range entries {
+		sortRoles(e.Roles)
+		matrix.Entries = append(matrix.Entries, *e)
+	}
+	sort.Slice(matrix.Entries, func(i, j int) bool {
+		return matrix.Entries[i].Address < matrix.Entries[j].Address
+	})
+	return matrix, nil
+}
+
+// LoadRoleMatrix 从 json 文件加载保存的角色矩阵
+func LoadRoleMatrix(filePath string) (*RoleMatrix, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	matrix := new(RoleMatrix)
+	if err := json.Unmarshal(bytes, matrix); err != nil {
+		return nil, err
+	}
+	return matrix, nil
+}
+
+// WriteFile 将角色矩阵保存为 json 文件
+func (matrix *RoleMatrix) WriteFile(filePath string) error {
+	bytes, err := json.MarshalIndent(matrix, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, bytes, 0644)
+}
+
+// Get 查询账户地址在矩阵中的记录
+func (matrix *RoleMatrix) Get(address string) (RoleMatrixEntry, bool) {
+	addr := common.HexToAddress(address)
+	for _, e := range matrix.Entries {
+		if common.HexToAddress(e.Address) == addr {
+			return e, true
+		}
+	}
+	return RoleMatrixEntry{}, false
+}
+
+// String 以表格的形式展示每个账户的角色
+func (matrix *RoleMatrix) String() string {
+	var b strings.Builder
+	b.WriteString("ADDRESS\tNAME")
+	for _, role := range AllRoles {
+		b.WriteString("\t" + string(role))
+	}
+	b.WriteString("\n")
+	for _, e := range matrix.Entries {
+		name := e.Name
+		if name == "" {
+			name = "-"
+		}
+		b.WriteString(e.Address + "\t" + name)
+		for _, role := range AllRoles {
+			mark := ""
+			for _, r := range e.Roles {
+				if r == role {
+					mark = "x"
+				}
+			}
+			b.WriteString("\t" + mark)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// RoleChange 两次快照之间一个账户的角色变化
+type RoleChange struct {
+	Address string `json:"address"`
+	Name    string `json:"name,omitempty"`
+	Granted []Role `json:"granted,omitempty"`
+	Revoked []Role `json:"revoked,omitempty"`
+}
+
+func (change RoleChange) String() string {
+	var parts []string
+	for _, role := range change.Granted {
+		parts = append(parts, "+"+string(role))
+	}
+	for _, role := range change.Revoked {
+		parts = append(parts, "-"+string(role))
+	}
+	return fmt.Sprintf("%s(%s): %s", change.Address, change.Name, strings.Join(parts, " "))
+}
+
+// Diff 对比之前的快照 old 和当前的矩阵，返回角色发生变化的账户，用于权限审计
+func (matrix *RoleMatrix) Diff(old *RoleMatrix) []RoleChange {
+	before := make(map[common.Address]RoleMatrixEntry)
+	after := make(map[common.Address]RoleMatrixEntry)
+	var addresses []common.Address
+	for _, e := range old.Entries {
+		addr := common.HexToAddress(e.Address)
+		before[addr] = e
+		addresses = append(addresses, addr)
+	}
+	for _, e := range matrix.Entries {
+		addr := common.HexToAddress(e.Address)
+		after[addr] = e
+		if _, ok := before[addr]; !ok {
+			addresses = append(addresses, addr)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+
+	var changes []RoleChange
+	for _, addr := range addresses {
+		b, a := before[addr], after[addr]
+		change := RoleChange{Address: addr.Hex(), Name: a.Name}
+		if change.Name == "" {
+			change.Name = b.Name
+		}
+		change.Granted = roleDiff(a.Roles, b.Roles)
+		change.Revoked = roleDiff(b.Roles, a.Roles)
+		if len(change.Granted) > 0 || len(change.Revoked) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// roleDiff 返回在 a 中但不在 b 中的角色
+func roleDiff(a, b []Role) []Role {
+	set := make(map[Role]bool)
+	for _, role := range b {
+		set[role] = true
+	}
+	var result []Role
+	for _, role := range a {
+		if !set[role] {
+			result = append(result, role)
+		}
+	}
+	return result
+}
@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// 模拟用户管理合约，roles 为每个地址拥有的角色
+type mockUserManager struct {
+	mockContract
+	users []syscontracts.UserInfo
+	roles map[common.Address][]string
+}
+
+func (mock *mockUserManager) address(name string) (common.Address, bool) {
+	for _, user := range mock.users {
+		if user.Name == name {
+			return user.Address, true
+		}
+	}
+	return common.Address{}, false
+}
+
+// transact 执行用户管理合约的交易，返回事件的 code 和 msg
+func (mock *mockUserManager) transact(method string, params []packet.DecodedParam) (uint64, string) {
+	switch method {
+	case "addUser":
+		var user syscontracts.UserInfo
+		_ = json.Unmarshal([]byte(params[0].Value.(string)), &user)
+		if _, ok := mock.address(user.Name); ok {
+			return 1, "user name exists"
+		}
+		mock.users = append(mock.users, user)
+		return 0, "success"
+	case "updateUserDescInfo":
+		for i := range mock.users {
+			if mock.users[i].Address == common.HexToAddress(params[0].Value.(string)) {
+				mock.users[i].DescInfo = params[1].Value.(string)
+				return 0, "success"
+			}
+		}
+		return 1, "user not found"
+	}
+	// add<Role>By<Address|Name> 和 del<Role>By<Address|Name>
+	target := params[0].Value.(string)
+	addr := common.HexToAddress(target)
+	if strings.HasSuffix(method, "ByName") {
+		var ok bool
+		if addr, ok = mock.address(target); !ok {
+			return 1, "user not found"
+		}
+	}
+	role, err := ParseRole(strings.TrimSuffix(strings.TrimSuffix(method[3:], "ByName"), "ByAddress"))
+	if err != nil {
+		return 1, err.Error()
+	}
+	var roles []string
+	for _, r := range mock.roles[addr] {
+		if r != string(role) {
+			roles = append(roles, r)
+		}
+	}
+	if strings.HasPrefix(method, "add") {
+		roles = append(roles, string(role))
+	}
+	mock.roles[addr] = roles
+	return 0, "success"
+}
+
+func (mock *mockUserManager) handlers(t *testing.T) map[string]mockHandler {
+	return mock.serve(t, func(call *packet.DecodedCall) interface{} {
+		arg := func(i int) string { return call.Params[i].Value.(string) }
+		switch call.Method {
+		case "getAllUsers":
+			return sysResult(mock.users)
+		case "getRolesByAddress":
+			return sysResult(append([]string{}, mock.roles[common.HexToAddress(arg(0))]...))
+		case "getRolesByName":
+			addr, _ := mock.address(arg(0))
+			return sysResult(append([]string{}, mock.roles[addr]...))
+		case "getAddrListOfRole":
+			addresses := []string{}
+			for addr, roles := range mock.roles {
+				for _, role := range roles {
+					if role == arg(0) {
+						addresses = append(addresses, addr.Hex())
+					}
+				}
+			}
+			return sysResult(addresses)
+		case "hasRole":
+			for _, role := range mock.roles[common.HexToAddress(arg(0))] {
+				if role == arg(1) {
+					return hexutil.Encode(common.LeftPadBytes([]byte{1}, 32))
+				}
+			}
+			return hexutil.Encode(common.LeftPadBytes([]byte{0}, 32))
+		}
+		t.Errorf("unexpected call %s", call.Method)
+		return nil
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		code, msg := mock.transact(call.Method, call.Params)
+		return []*packet.Log{testSysLog(precompile.UserManagementAddress, call.Method, code, msg)}
+	})
+}
+
+func TestParseRole(t *testing.T) {
+	for _, s := range []string{"CHAIN_ADMIN", "chainAdmin", "chainadmin", " Chain_Admin "} {
+		role, err := ParseRole(s)
+		assert.NoError(t, err)
+		assert.Equal(t, RoleChainAdmin, role)
+	}
+	_, err := ParseRole("chainCreator")
+	assert.Error(t, err)
+}
+
+func TestRoleClient_RoleMatrix(t *testing.T) {
+	alice := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	bob := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	admin := common.HexToAddress("0x1000000000000000000000000000000000000003")
+	mock := &mockUserManager{
+		users: []syscontracts.UserInfo{{Address: alice, Name: "alice"}, {Address: bob, Name: "bob"}},
+		roles: map[common.Address][]string{
+			alice: {"CHAIN_ADMIN", "CONTRACT_DEPLOYER"},
+			admin: {"SUPER_ADMIN"},
+		},
+	}
+	server, url := newMockNode(t, mock.handlers(t))
+	defer server.Close()
+	roleClient, err := NewRoleClientWithKey(context.Background(), url, newTestKey(t))
+	assert.NoError(t, err)
+	defer roleClient.RpcClient.Close()
+	ctx := context.Background()
+
+	before, err := roleClient.RoleMatrix(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, before.Entries, 3)
+	entry, ok := before.Get(admin.Hex())
+	assert.True(t, ok)
+	assert.Equal(t, "", entry.Name)
+	assert.Equal(t, []Role{RoleSuperAdmin}, entry.Roles)
+	entry, _ = before.Get(alice.Hex())
+	assert.Equal(t, []Role{RoleChainAdmin, RoleContractDeployer}, entry.Roles)
+
+	_, err = roleClient.GrantRoleByName(ctx, "bob", RoleNodeAdmin)
+	assert.NoError(t, err)
+	_, err = roleClient.RevokeRole(ctx, alice.Hex(), RoleChainAdmin)
+	assert.NoError(t, err)
+	_, err = roleClient.GrantRoleByName(ctx, "carol", RoleNodeAdmin)
+	assert.Error(t, err)
+	// 超级管理员不能通过授予角色设置
+	_, err = roleClient.GrantRole(ctx, bob.Hex(), RoleSuperAdmin)
+	assert.Error(t, err)
+	assert.Len(t, mock.receipts, 3)
+
+	roles, err := roleClient.RolesOf(ctx, bob.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, []Role{RoleNodeAdmin}, roles)
+	roles, err = roleClient.RolesByName(ctx, "alice")
+	assert.NoError(t, err)
+	assert.Equal(t, []Role{RoleContractDeployer}, roles)
+	has, err := roleClient.CheckRole(ctx, bob.Hex(), RoleNodeAdmin)
+	assert.NoError(t, err)
+	assert.True(t, has)
+	has, err = roleClient.CheckRole(ctx, alice.Hex(), RoleChainAdmin)
+	assert.NoError(t, err)
+	assert.False(t, has)
+
+	var names []string
+	it := roleClient.Users()
+	for it.Next(ctx) {
+		names = append(names, it.User().Name)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"alice", "bob"}, names)
+
+	// 保存快照后与当前的矩阵对比
+	path := filepath.Join(t.TempDir(), "roles.json")
+	assert.NoError(t, before.WriteFile(path))
+	saved, err := LoadRoleMatrix(path)
+	assert.NoError(t, err)
+	after, err := roleClient.RoleMatrix(ctx)
+	assert.NoError(t, err)
+	changes := after.Diff(saved)
+	assert.Equal(t, []RoleChange{
+		{Address: alice.Hex(), Name: "alice", Revoked: []Role{RoleChainAdmin}},
+		{Address: bob.Hex(), Name: "bob", Granted: []Role{RoleNodeAdmin}},
+	}, changes)
+	assert.Empty(t, after.Diff(after))
+	assert.Contains(t, after.String(), "bob")
+}
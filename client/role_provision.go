@@ -0,0 +1,322 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"gopkg.in/yaml.v2"
+)
+
+// 每个角色可以由哪些角色授予和撤销
+var roleGranters = map[Role][]Role{
+	RoleChainAdmin:       {RoleSuperAdmin},
+	RoleGroupAdmin:       {RoleSuperAdmin, RoleChainAdmin},
+	RoleNodeAdmin:        {RoleSuperAdmin, RoleChainAdmin},
+	RoleContractAdmin:    {RoleSuperAdmin, RoleChainAdmin},
+	RoleContractDeployer: {RoleSuperAdmin, RoleChainAdmin, RoleContractAdmin},
+}
+
+// CanGrant 拥有 roles 的账户是否可以授予或撤销 role
+func CanGrant(roles []Role, role Role) bool {
+	for _, granter := range roleGranters[role] {
+		for _, r := range roles {
+			if r == granter {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ManifestUser 清单中的用户及其应当拥有的角色
+type ManifestUser struct {
+	Name         string `json:"name" yaml:"name"`
+	Address      string `json:"address" yaml:"address"`
+	Phone        string `json:"phone,omitempty" yaml:"phone,omitempty"`
+	Email        string `json:"email,omitempty" yaml:"email,omitempty"`
+	Organization string `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Roles        []Role `json:"roles" yaml:"roles"`
+}
+
+// RoleManifest 声明式的用户和角色清单，可以保存为 yaml 或 json 文件
+// 只管理清单中列出的用户，清单中用户多出的角色会被撤销
+type RoleManifest struct {
+	Users []ManifestUser `json:"users" yaml:"users"`
+}
+
+// ParseRoleManifest 解析 yaml 或 json 格式的清单，角色名统一为 Role 中的常量
+func ParseRoleManifest(data []byte) (*RoleManifest, error) {
+	manifest := new(RoleManifest)
+	// json 是 yaml 的子集
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	if err := manifest.normalize(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func LoadRoleManifest(filePath string) (*RoleManifest, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRoleManifest(data)
+}
+
+// WriteFile 保存清单，文件扩展名为 .json 时保存为 json，否则保存为 yaml
+func (manifest *RoleManifest) WriteFile(filePath string) error {
+	var (
+		data []byte
+		err  error
+	)
+	if strings.HasSuffix(strings.ToLower(filePath), ".json") {
+		data, err = json.MarshalIndent(manifest, "", "  ")
+	} else {
+		data, err = yaml.Marshal(manifest)
+	}
+	if err != nil {
+		return err
+	}
+	return packet.WriteFile(data, filePath)
+}
+
+// normalize 检查清单中的用户，地址和角色统一格式
+func (manifest *RoleManifest) normalize() error {
+	names := make(map[string]bool)
+	addresses := make(map[common.Address]bool)
+	for i := range manifest.Users {
+		user := &manifest.Users[i]
+		if user.Name == "" {
+			return fmt.Errorf("the name of user %s is empty", user.Address)
+		}
+		if !packet.IsMatch(user.Address, "address") {
+			return fmt.Errorf("invalid address %s of user %s", user.Address, user.Name)
+		}
+		addr := common.HexToAddress(user.Address)
+		if names[user.Name] || addresses[addr] {
+			return fmt.Errorf("the user %s(%s) is duplicated in the manifest", user.Name, user.Address)
+		}
+		names[user.Name], addresses[addr] = true, true
+		user.Address = addr.Hex()
+		roles := make([]Role, 0, len(user.Roles))
+		for _, r := range user.Roles {
+			role, err := ParseRole(string(r))
+			if err != nil {
+				return fmt.Errorf("user %s: %v", user.Name, err)
+			}
+			if role == RoleSuperAdmin {
+				return fmt.Errorf("user %s: the role %s can not be provisioned", user.Name, role)
+			}
+			if !containsRole(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+		sortRoles(roles)
+		user.Roles = roles
+	}
+	return nil
+}
+
+func containsRole(roles []Role, role Role) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// 清单执行的操作
+const (
+	ProvisionAddUser    = "add-user"
+	ProvisionUpdateUser = "update-user"
+	ProvisionGrant      = "grant"
+	ProvisionRevoke     = "revoke"
+)
+
+// ProvisionStep 清单执行计划中的一步，Role 只在授予和撤销角色时不为空
+type ProvisionStep struct {
+	Action  string       `json:"action"`
+	User    ManifestUser `json:"user"`
+	Role    Role         `json:"role,omitempty"`
+	Current string       `json:"current,omitempty"` // 更新用户信息时链上当前的描述信息
+}
+
+func (step ProvisionStep) String() string {
+	switch step.Action {
+	case ProvisionAddUser:
+		return fmt.Sprintf("+ user %s(%s)", step.User.Name, step.User.Address)
+	case ProvisionUpdateUser:
+		return fmt.Sprintf("~ user %s(%s): %s -> %s", step.User.Name, step.User.Address, step.Current,
+			userDescInfoParam(step.User.Phone, step.User.Email, step.User.Organization))
+	case ProvisionGrant:
+		return fmt.Sprintf("+ role %s to %s(%s)", step.Role, step.User.Name, step.User.Address)
+	default:
+		return fmt.Sprintf("- role %s from %s(%s)", step.Role, step.User.Name, step.User.Address)
+	}
+}
+
+// ProvisionPlan 清单与链上状态的差异
+// 执行顺序为：新增用户、更新用户信息、从高到低授予角色、从低到高撤销角色
+// Errors 为当前账户无权执行的操作或者与链上状态的冲突，不为空时不能执行
+type ProvisionPlan struct {
+	Operator string          `json:"operator"`
+	Steps    []ProvisionStep `json:"steps"`
+	Errors   []string        `json:"errors,omitempty"`
+}
+
+func (plan *ProvisionPlan) Empty() bool {
+	return len(plan.Steps) == 0
+}
+
+// String 以 diff 的形式展示的演练报告
+func (plan *ProvisionPlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "operator %s\n", plan.Operator)
+	if plan.Empty() {
+		b.WriteString("no changes\n")
+	}
+	for _, step := range plan.Steps {
+		b.WriteString(step.String() + "\n")
+	}
+	for _, e := range plan.Errors {
+		b.WriteString("! " + e + "\n")
+	}
+	return b.String()
+}
+
+// PlanProvision 对比清单和链上的用户和角色，生成执行计划，不发送交易
+func (roleClient RoleClient) PlanProvision(ctx context.Context, manifest *RoleManifest) (*ProvisionPlan, error) {
+	users, err := roleClient.GetAllUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byAddress := make(map[common.Address]syscontracts.UserInfo)
+	byName := make(map[string]syscontracts.UserInfo)
+	for _, user := range users {
+		byAddress[user.Address] = user
+		byName[user.Name] = user
+	}
+	operatorRoles, err := roleClient.RolesOf(ctx, roleClient.Key.Address.Hex())
+	if err != nil {
+		return nil, err
+	}
+	plan := &ProvisionPlan{Operator: roleClient.Key.Address.Hex()}
+
+	var adds, updates, grants, revokes []ProvisionStep
+	for _, user := range manifest.Users {
+		addr := common.HexToAddress(user.Address)
+		var current []Role
+		if existing, ok := byAddress[addr]; ok {
+			if existing.Name != user.Name {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("the address %s is registered as %s, not %s", user.Address, existing.Name, user.Name))
+				continue
+			}
+			desc := userDescInfoParam(user.Phone, user.Email, user.Organization)
+			if !sameUserDescInfo(existing.DescInfo, desc) {
+				updates = append(updates, ProvisionStep{Action: ProvisionUpdateUser, User: user, Current: existing.DescInfo})
+			}
+			if current, err = roleClient.RolesOf(ctx, user.Address); err != nil {
+				return nil, err
+			}
+		} else {
+			if other, ok := byName[user.Name]; ok {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("the name %s is registered by %s, not %s", user.Name, other.Address.Hex(), user.Address))
+				continue
+			}
+			adds = append(adds, ProvisionStep{Action: ProvisionAddUser, User: user})
+		}
+		for _, role := range roleDiff(user.Roles, current) {
+			grants = append(grants, ProvisionStep{Action: ProvisionGrant, User: user, Role: role})
+		}
+		for _, role := range roleDiff(current, user.Roles) {
+			if role == RoleSuperAdmin {
+				continue
+			}
+			revokes = append(revokes, ProvisionStep{Action: ProvisionRevoke, User: user, Role: role})
+		}
+	}
+	sortSteps(grants, false)
+	sortSteps(revokes, true)
+	plan.Steps = append(append(append(adds, updates...), grants...), revokes...)
+
+	for _, step := range plan.Steps {
+		if step.Role != "" && !CanGrant(operatorRoles, step.Role) {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("the operator can not %s the role %s, it requires one of %v", step.Action, step.Role, roleGranters[step.Role]))
+		}
+	}
+	return plan, nil
+}
+
+// 授予角色时按照权限从高到低，撤销时从低到高，同一个角色保持清单中的顺序
+func sortSteps(steps []ProvisionStep, reverse bool) {
+	sort.SliceStable(steps, func(i, j int) bool {
+		if reverse {
+			return roleRank(steps[i].Role) > roleRank(steps[j].Role)
+		}
+		return roleRank(steps[i].Role) < roleRank(steps[j].Role)
+	})
+}
+
+func sameUserDescInfo(a, b string) bool {
+	var da, db syscontracts.UserDescInfo
+	// 链上没有描述信息时与空的描述信息相同
+	if a != "" && json.Unmarshal([]byte(a), &da) != nil {
+		return a == b
+	}
+	_ = json.Unmarshal([]byte(b), &db)
+	return da == db
+}
+
+// ApplyProvision 按照计划发送交易，完成后重新对比链上的状态
+func (roleClient RoleClient) ApplyProvision(ctx context.Context, plan *ProvisionPlan) error {
+	if len(plan.Errors) > 0 {
+		return errors.New("the provision plan has errors:\n" + strings.Join(plan.Errors, "\n"))
+	}
+	manifest := new(RoleManifest)
+	seen := make(map[string]bool)
+	for _, step := range plan.Steps {
+		var err error
+		user := step.User
+		switch step.Action {
+		case ProvisionAddUser:
+			_, err = roleClient.sysContractTransact(ctx, precompile.UserManagementAddress, "addUser",
+				userInfoParam(common.HexToAddress(user.Address), user.Name, user.Phone, user.Email, user.Organization))
+		case ProvisionUpdateUser:
+			_, err = roleClient.sysContractTransact(ctx, precompile.UserManagementAddress, "updateUserDescInfo",
+				user.Address, userDescInfoParam(user.Phone, user.Email, user.Organization))
+		case ProvisionGrant:
+			_, err = roleClient.GrantRole(ctx, user.Address, step.Role)
+		case ProvisionRevoke:
+			_, err = roleClient.RevokeRole(ctx, user.Address, step.Role)
+		default:
+			err = fmt.Errorf("unknown action %s", step.Action)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", step, err)
+		}
+		if !seen[user.Address] {
+			seen[user.Address] = true
+			manifest.Users = append(manifest.Users, user)
+		}
+	}
+	remaining, err := roleClient.PlanProvision(ctx, manifest)
+	if err != nil {
+		return err
+	}
+	if !remaining.Empty() {
+		return fmt.Errorf("the provision is not completed:\n%s", remaining)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoleManifest = `
+users:
+  - name: alice
+    address: "0x1000000000000000000000000000000000000001"
+    email: alice@example.com
+    organization: org1
+    roles: [contractDeployer, CHAIN_ADMIN]
+  - name: bob
+    address: "0x1000000000000000000000000000000000000002"
+    roles: [node_admin]
+`
+
+func TestParseRoleManifest(t *testing.T) {
+	manifest, err := ParseRoleManifest([]byte(testRoleManifest))
+	assert.NoError(t, err)
+	assert.Equal(t, []Role{RoleChainAdmin, RoleContractDeployer}, manifest.Users[0].Roles)
+	assert.Equal(t, "0x1000000000000000000000000000000000000001", manifest.Users[0].Address)
+
+	path := filepath.Join(t.TempDir(), "roles.json")
+	assert.NoError(t, manifest.WriteFile(path))
+	loaded, err := LoadRoleManifest(path)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, loaded)
+
+	for _, data := range []string{
+		"users: [{name: a, address: 0x1, roles: []}]",
+		"users: [{name: a, address: \"0x1000000000000000000000000000000000000001\", roles: [SUPER_ADMIN]}]",
+		"users: [{name: a, address: \"0x1000000000000000000000000000000000000001\", roles: [admin]}]",
+		"users: [{name: a, address: \"0x1000000000000000000000000000000000000001\"}, {name: a, address: \"0x1000000000000000000000000000000000000002\"}]",
+	} {
+		_, err := ParseRoleManifest([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestRoleClient_Provision(t *testing.T) {
+	operator := newTestKey(t).Address
+	alice := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	bob := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	mock := &mockUserManager{
+		users: []syscontracts.UserInfo{{Address: bob, Name: "bob"}},
+		roles: map[common.Address][]string{
+			operator: {"CHAIN_ADMIN"},
+			bob:      {"CONTRACT_ADMIN", "CONTRACT_DEPLOYER"},
+		},
+	}
+	server, url := newMockNode(t, mock.handlers(t))
+	defer server.Close()
+	roleClient, err := NewRoleClientWithKey(context.Background(), url, newTestKey(t))
+	assert.NoError(t, err)
+	defer roleClient.RpcClient.Close()
+	ctx := context.Background()
+	manifest, err := ParseRoleManifest([]byte(testRoleManifest))
+	assert.NoError(t, err)
+
+	// 链管理员不能授予链管理员角色，演练报告中列出错误，不发送交易
+	plan, err := roleClient.PlanProvision(ctx, manifest)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Errors, 1)
+	assert.Contains(t, plan.String(), "! the operator can not grant the role CHAIN_ADMIN")
+	assert.Error(t, roleClient.ApplyProvision(ctx, plan))
+	assert.Empty(t, mock.receipts)
+
+	mock.roles[operator] = []string{"SUPER_ADMIN"}
+	plan, err = roleClient.PlanProvision(ctx, manifest)
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Errors)
+	var steps []string
+	for _, step := range plan.Steps {
+		steps = append(steps, step.String())
+	}
+	assert.Equal(t, []string{
+		"+ user alice(" + alice.Hex() + ")",
+		"+ role CHAIN_ADMIN to alice(" + alice.Hex() + ")",
+		"+ role NODE_ADMIN to bob(" + bob.Hex() + ")",
+		"+ role CONTRACT_DEPLOYER to alice(" + alice.Hex() + ")",
+		"- role CONTRACT_DEPLOYER from bob(" + bob.Hex() + ")",
+		"- role CONTRACT_ADMIN from bob(" + bob.Hex() + ")",
+	}, steps)
+
+	assert.NoError(t, roleClient.ApplyProvision(ctx, plan))
+	assert.Len(t, mock.receipts, 6)
+	assert.Equal(t, "alice", mock.users[1].Name)
+	assert.Contains(t, mock.users[1].DescInfo, "alice@example.com")
+
+	plan, err = roleClient.PlanProvision(ctx, manifest)
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty())
+
+	// 修改用户的描述信息
+	manifest.Users[1].Phone = "12345678901"
+	plan, err = roleClient.PlanProvision(ctx, manifest)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Steps, 1)
+	assert.Equal(t, ProvisionUpdateUser, plan.Steps[0].Action)
+	assert.NoError(t, roleClient.ApplyProvision(ctx, plan))
+
+	// 用户名已经被其他地址注册
+	manifest.Users[1].Address = "0x1000000000000000000000000000000000000009"
+	plan, err = roleClient.PlanProvision(ctx, manifest)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Errors, 1)
+}
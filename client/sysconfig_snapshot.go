@@ -0,0 +1,301 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/rpc"
+	"github.com/Venachain/client-sdk-go/venachain/vm"
+)
+
+// SysConfig 系统参数的快照，开关类的参数在链上为 uint32 的 1 和 0
+type SysConfig struct {
+	TxGasLimit                      uint64 `json:"txGasLimit"`
+	BlockGasLimit                   uint64 `json:"blockGasLimit"`
+	IsTxUseGas                      bool   `json:"isTxUseGas"`
+	IsApproveDeployedContract       bool   `json:"isApproveDeployedContract"`
+	IsCheckContractDeployPermission bool   `json:"isCheckContractDeployPermission"`
+	IsProduceEmptyBlock             bool   `json:"isProduceEmptyBlock"`
+	IsBlockUseTrieHash              bool   `json:"isBlockUseTrieHash"`
+	GasContractName                 string `json:"gasContractName"`
+	VRFParams                       string `json:"vrfParams"`
+}
+
+// sysConfigField 系统参数与合约接口的对应关系，get 和 set 在 SysConfig 中读写参数的值
+type sysConfigField struct {
+	key     string
+	getter  string
+	setter  string
+	get     func(config *SysConfig) string
+	set     func(config *SysConfig, value interface{})
+	isValid func(config *SysConfig) error
+}
+
+func flagString(flag bool) string {
+	if flag {
+		return "1"
+	}
+	return "0"
+}
+
+func flagValue(value interface{}) bool {
+	v, _ := value.(uint32)
+	return v == 1
+}
+
+// 与 vm 中的参数名一致，顺序即为 GetAll 查询和 Apply 发送交易的顺序
+var sysConfigFields = []sysConfigField{
+	{
+		key: vm.TxGasLimitKey, getter: "getTxGasLimit", setter: "setTxGasLimit",
+		get: func(c *SysConfig) string { return strconv.FormatUint(c.TxGasLimit, 10) },
+		set: func(c *SysConfig, v interface{}) { c.TxGasLimit, _ = v.(uint64) },
+		isValid: func(c *SysConfig) error {
+			if c.TxGasLimit < vm.TxGasLimitMinValue || c.TxGasLimit > vm.TxGasLimitMaxValue {
+				return fmt.Errorf("the transaction gas limit %d should be within [%d, %d]", c.TxGasLimit, vm.TxGasLimitMinValue, vm.TxGasLimitMaxValue)
+			}
+			return nil
+		},
+	},
+	{
+		key: vm.BlockGasLimitKey, getter: "getBlockGasLimit", setter: "setBlockGasLimit",
+		get: func(c *SysConfig) string { return strconv.FormatUint(c.BlockGasLimit, 10) },
+		set: func(c *SysConfig, v interface{}) { c.BlockGasLimit, _ = v.(uint64) },
+		isValid: func(c *SysConfig) error {
+			if c.BlockGasLimit < vm.BlockGasLimitMinValue || c.BlockGasLimit > vm.BlockGasLimitMaxValue {
+				return fmt.Errorf("the block gas limit %d should be within [%d, %d]", c.BlockGasLimit, vm.BlockGasLimitMinValue, vm.BlockGasLimitMaxValue)
+			}
+			if c.BlockGasLimit < c.TxGasLimit {
+				return fmt.Errorf("the block gas limit %d should not be less than the transaction gas limit %d", c.BlockGasLimit, c.TxGasLimit)
+			}
+			return nil
+		},
+	},
+	{
+		key: vm.IsTxUseGasKey, getter: "getIsTxUseGas", setter: "setIsTxUseGas",
+		get: func(c *SysConfig) string { return flagString(c.IsTxUseGas) },
+		set: func(c *SysConfig, v interface{}) { c.IsTxUseGas = flagValue(v) },
+	},
+	{
+		key: vm.IsApproveDeployedContractKey, getter: "getIsApproveDeployedContract", setter: "setIsApproveDeployedContract",
+		get: func(c *SysConfig) string { return flagString(c.IsApproveDeployedContract) },
+		set: func(c *SysConfig, v interface{}) { c.IsApproveDeployedContract = flagValue(v) },
+	},
+	{
+		key: vm.IsCheckContractDeployPermissionKey, getter: "getCheckContractDeployPermission", setter: "setCheckContractDeployPermission",
+		get: func(c *SysConfig) string { return flagString(c.IsCheckContractDeployPermission) },
+		set: func(c *SysConfig, v interface{}) { c.IsCheckContractDeployPermission = flagValue(v) },
+	},
+	{
+		key: vm.IsProduceEmptyBlockKey, getter: "getIsProduceEmptyBlock", setter: "setIsProduceEmptyBlock",
+		get: func(c *SysConfig) string { return flagString(c.IsProduceEmptyBlock) },
+		set: func(c *SysConfig, v interface{}) { c.IsProduceEmptyBlock = flagValue(v) },
+	},
+	{
+		key: vm.IsBlockUseTrieHashKey, getter: "getIsBlockUseTrieHash", setter: "setIsBlockUseTrieHash",
+		get: func(c *SysConfig) string { return flagString(c.IsBlockUseTrieHash) },
+		set: func(c *SysConfig, v interface{}) { c.IsBlockUseTrieHash = flagValue(v) },
+	},
+	{
+		key: vm.GasContractNameKey, getter: "getGasContractName", setter: "setGasContractName",
+		get: func(c *SysConfig) string { return c.GasContractName },
+		set: func(c *SysConfig, v interface{}) { c.GasContractName, _ = v.(string) },
+	},
+	{
+		key: vm.VrfParamsKey, getter: "getVRFParams", setter: "setVRFParams",
+		get: func(c *SysConfig) string { return c.VRFParams },
+		set: func(c *SysConfig, v interface{}) { c.VRFParams, _ = v.(string) },
+		isValid: func(c *SysConfig) error {
+			if c.VRFParams != "" && !json.Valid([]byte(c.VRFParams)) {
+				return fmt.Errorf("the vrf params %s is not a valid json", c.VRFParams)
+			}
+			return nil
+		},
+	},
+}
+
+// Validate 按照 vm 中的取值范围检查参数
+func (config *SysConfig) Validate() error {
+	for _, field := range sysConfigFields {
+		if field.isValid == nil {
+			continue
+		}
+		if err := field.isValid(config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SysConfigChange 一个参数的修改，Old 和 New 为发送给合约的参数值
+type SysConfigChange struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func (change SysConfigChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", change.Key, change.Old, change.New)
+}
+
+// Diff 返回从当前快照修改为 desired 需要修改的参数
+func (config *SysConfig) Diff(desired *SysConfig) []SysConfigChange {
+	var changes []SysConfigChange
+	for _, field := range sysConfigFields {
+		old, value := field.get(config), field.get(desired)
+		if old != value {
+			changes = append(changes, SysConfigChange{Key: field.key, Old: old, New: value})
+		}
+	}
+	return changes
+}
+
+func (config *SysConfig) String() string {
+	var b strings.Builder
+	for _, field := range sysConfigFields {
+		fmt.Fprintf(&b, "%s: %s\n", field.key, field.get(config))
+	}
+	return b.String()
+}
+
+func sysConfigFieldByKey(key string) (sysConfigField, bool) {
+	for _, field := range sysConfigFields {
+		if field.key == key {
+			return field, true
+		}
+	}
+	return sysConfigField{}, false
+}
+
+// GetAll 在一个 json rpc 批量请求中查询所有的系统参数
+func (sysConfigClient SysConfigClient) GetAll(ctx context.Context) (*SysConfig, error) {
+	dataGens := make([]*packet.ContractDataGen, len(sysConfigFields))
+	batch := make([]rpc.BatchElem, len(sysConfigFields))
+	for i, field := range sysConfigFields {
+		dataGen, err := sysConfigClient.MakeContractGenerator(precompile.ParameterManagementAddress, nil, field.getter)
+		if err != nil {
+			return nil, err
+		}
+		txparam, err := dataGen.MakeTxparamForContract(&sysConfigClient.Key.Address, &dataGen.To)
+		if err != nil {
+			return nil, err
+		}
+		dataGens[i] = dataGen
+		batch[i] = rpc.BatchElem{Method: "eth_call", Args: []interface{}{txparam, "latest"}, Result: new(string)}
+	}
+	if err := sysConfigClient.RpcClient.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	config := new(SysConfig)
+	for i, field := range sysConfigFields {
+		if batch[i].Error != nil {
+			return nil, fmt.Errorf("get %s error: %v", field.key, batch[i].Error)
+		}
+		res := dataGens[i].ParseNonConstantResponse(*batch[i].Result.(*string), dataGens[i].GetMethodAbi().Outputs)
+		if len(res) == 0 {
+			return nil, fmt.Errorf("get %s error: empty result", field.key)
+		}
+		field.set(config, res[0])
+	}
+	return config, nil
+}
+
+// SysConfigResult 修改一个参数的交易结果，Event 为回执中合约返回的事件
+type SysConfigResult struct {
+	SysConfigChange
+	TxHash string   `json:"txHash"`
+	Event  SysEvent `json:"event"`
+}
+
+// ApplySysConfig 检查 desired 后只对修改的参数发送交易
+// 某个参数修改失败时停止，返回已经完成的修改和错误
+func (sysConfigClient SysConfigClient) ApplySysConfig(ctx context.Context, desired *SysConfig) ([]SysConfigResult, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+	current, err := sysConfigClient.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var results []SysConfigResult
+	for _, change := range current.Diff(desired) {
+		field, _ := sysConfigFieldByKey(change.Key)
+		result, err := sysConfigClient.sysConfigTransact(ctx, field.setter, change.New)
+		if err != nil {
+			return results, fmt.Errorf("set %s error: %v", change.Key, err)
+		}
+		result.SysConfigChange = change
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// sysConfigTransact 发送修改参数的交易，解析回执中的事件，事件的 code 不为 0 时返回错误
+func (sysConfigClient SysConfigClient) sysConfigTransact(ctx context.Context, funcName string, funcParams ...string) (*SysConfigResult, error) {
+	receipt, err := sysConfigClient.sysContractTransact(ctx, precompile.ParameterManagementAddress, funcName, funcParams...)
+	if err != nil {
+		return nil, err
+	}
+	events, err := ParseSysEvents(receipt, sysConfigClient.ContractContent.GetEvents())
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.New("the event is not found in the receipt")
+	}
+	return &SysConfigResult{TxHash: receipt.TransactionHash, Event: events[0]}, nil
+}
+
+// GetIntParam 按照参数名查询整数类型的系统参数
+func (sysConfigClient SysConfigClient) GetIntParam(ctx context.Context, key string) (uint64, error) {
+	result, err := sysConfigClient.contractCallWithParams(ctx, []string{key}, "getIntParam", precompile.ParameterManagementAddress)
+	if err != nil {
+		return 0, err
+	}
+	res := result.([]interface{})
+	return res[0].(uint64), nil
+}
+
+// GetStrParam 按照参数名查询字符串类型的系统参数
+func (sysConfigClient SysConfigClient) GetStrParam(ctx context.Context, key string) (string, error) {
+	result, err := sysConfigClient.contractCallWithParams(ctx, []string{key}, "getStrParam", precompile.ParameterManagementAddress)
+	if err != nil {
+		return "", err
+	}
+	res := result.([]interface{})
+	return res[0].(string), nil
+}
+
+// SetIntParam 按照参数名设置整数类型的系统参数，已知的参数先检查取值范围
+func (sysConfigClient SysConfigClient) SetIntParam(ctx context.Context, key string, value uint64) (*SysConfigResult, error) {
+	if key == vm.TxGasLimitKey || key == vm.BlockGasLimitKey {
+		if !checkConfigParam(strconv.FormatUint(value, 10), key) {
+			return nil, fmt.Errorf("the %s %d is out of range", key, value)
+		}
+	}
+	return sysConfigClient.sysConfigTransact(ctx, "setIntParam", key, strconv.FormatUint(value, 10))
+}
+
+// SetStrParam 按照参数名设置字符串类型的系统参数
+func (sysConfigClient SysConfigClient) SetStrParam(ctx context.Context, key string, value string) (*SysConfigResult, error) {
+	return sysConfigClient.sysConfigTransact(ctx, "setStrParam", key, value)
+}
+
+func (sysConfigClient SysConfigClient) GetIsBlockUseTrieHash(ctx context.Context) (uint32, error) {
+	funcName := "getIsBlockUseTrieHash"
+	result, err := sysConfigClient.contractCallWithParams(ctx, nil, funcName, precompile.ParameterManagementAddress)
+	if err != nil {
+		return 0, err
+	}
+	res := result.([]interface{})
+	return res[0].(uint32), nil
+}
+
+func (sysConfigClient SysConfigClient) SetIsBlockUseTrieHash(ctx context.Context, isBlockUseTrieHash bool) (*SysConfigResult, error) {
+	return sysConfigClient.sysConfigTransact(ctx, "setIsBlockUseTrieHash", flagString(isBlockUseTrieHash))
+}
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+// 模拟参数管理合约，params 按照参数名保存参数的值
+type mockParamManager struct {
+	mockContract
+	params map[string]string
+	calls  []string
+}
+
+// 合约接口名去掉 get 和 set 前缀后对应的参数名
+func paramKey(method string) string {
+	key := method[3:]
+	if key == "CheckContractDeployPermission" {
+		return vm.IsCheckContractDeployPermissionKey
+	}
+	return key
+}
+
+func (mock *mockParamManager) handlers(t *testing.T) map[string]mockHandler {
+	return mock.serve(t, func(call *packet.DecodedCall) interface{} {
+		mock.calls = append(mock.calls, call.Method)
+		key := paramKey(call.Method)
+		if call.Method == "getIntParam" || call.Method == "getStrParam" {
+			key = call.Params[0].Value.(string)
+		}
+		value := mock.params[key]
+		if call.Method == "getGasContractName" || call.Method == "getVRFParams" || call.Method == "getStrParam" {
+			return wasmStringResult(value)
+		}
+		n, _ := new(big.Int).SetString(value, 10)
+		return hexutil.Encode(common.LeftPadBytes(n.Bytes(), 32))
+	}, func(call *packet.DecodedCall) []*packet.Log {
+		mock.calls = append(mock.calls, call.Method)
+		event, key, value := paramKey(call.Method), paramKey(call.Method), fmt.Sprint(call.Params[0].Value)
+		if call.Method == "setIntParam" || call.Method == "setStrParam" {
+			event, key, value = "Notify", value, fmt.Sprint(call.Params[1].Value)
+		}
+		var code uint64
+		msg := "success"
+		if key == vm.GasContractNameKey && value == "unknown" {
+			code, msg = 1, "the contract is not registered"
+		} else {
+			mock.params[key] = value
+		}
+		return []*packet.Log{testSysLog(precompile.ParameterManagementAddress, event, code, msg)}
+	})
+}
+
+func (mock *mockParamManager) sent() []string {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	var sent []string
+	for _, method := range mock.calls {
+		if strings.HasPrefix(method, "set") {
+			sent = append(sent, method)
+		}
+	}
+	mock.calls = nil
+	return sent
+}
+
+func TestSysConfig_Validate(t *testing.T) {
+	config := &SysConfig{TxGasLimit: 1.5e9, BlockGasLimit: 1e10}
+	assert.NoError(t, config.Validate())
+	config.TxGasLimit = vm.TxGasLimitMaxValue + 1
+	assert.Error(t, config.Validate())
+	config.TxGasLimit, config.BlockGasLimit = vm.TxGasLimitMaxValue, vm.BlockGasLimitMinValue
+	assert.Error(t, config.Validate())
+	config.BlockGasLimit = vm.BlockGasLimitMaxValue
+	config.VRFParams = "{"
+	assert.Error(t, config.Validate())
+}
+
+func TestSysConfigClient_GetAllAndApply(t *testing.T) {
+	mock := &mockParamManager{
+		params: map[string]string{
+			vm.TxGasLimitKey:                      "1500000000",
+			vm.BlockGasLimitKey:                   "10000000000",
+			vm.IsTxUseGasKey:                      "0",
+			vm.IsApproveDeployedContractKey:       "0",
+			vm.IsCheckContractDeployPermissionKey: "1",
+			vm.IsProduceEmptyBlockKey:             "0",
+			vm.IsBlockUseTrieHashKey:              "1",
+			vm.GasContractNameKey:                 "",
+			vm.VrfParamsKey:                       `{"electionEpoch":0}`,
+		},
+	}
+	server, url := newMockNode(t, mock.handlers(t))
+	defer server.Close()
+	client, err := NewSysConfigClientWithKey(context.Background(), url, newTestKey(t))
+	assert.NoError(t, err)
+	defer client.RpcClient.Close()
+	ctx := context.Background()
+
+	config, err := client.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &SysConfig{
+		TxGasLimit:                      1.5e9,
+		BlockGasLimit:                   1e10,
+		IsCheckContractDeployPermission: true,
+		IsBlockUseTrieHash:              true,
+		VRFParams:                       `{"electionEpoch":0}`,
+	}, config)
+	assert.Empty(t, mock.sent())
+
+	desired := *config
+	desired.TxGasLimit = 1.9e9
+	desired.IsProduceEmptyBlock = true
+	desired.GasContractName = "gas"
+	assert.Equal(t, []SysConfigChange{
+		{Key: vm.TxGasLimitKey, Old: "1500000000", New: "1900000000"},
+		{Key: vm.IsProduceEmptyBlockKey, Old: "0", New: "1"},
+		{Key: vm.GasContractNameKey, Old: "", New: "gas"},
+	}, config.Diff(&desired))
+
+	results, err := client.ApplySysConfig(ctx, &desired)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, vm.IsProduceEmptyBlockKey, results[1].Key)
+	assert.Equal(t, SysEvent{Name: vm.IsProduceEmptyBlockKey, Msg: "success"}, results[1].Event)
+	assert.Equal(t, []string{"setTxGasLimit", "setIsProduceEmptyBlock", "setGasContractName"}, mock.sent())
+	config, err = client.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &desired, config)
+
+	// 超出范围的参数不发送交易
+	desired.BlockGasLimit = vm.BlockGasLimitMaxValue + 1
+	_, err = client.ApplySysConfig(ctx, &desired)
+	assert.Error(t, err)
+	assert.Empty(t, mock.sent())
+
+	// 修改失败时返回已经完成的修改
+	desired.BlockGasLimit = vm.BlockGasLimitMaxValue
+	desired.GasContractName = "unknown"
+	results, err = client.ApplySysConfig(ctx, &desired)
+	assert.Error(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, vm.BlockGasLimitKey, results[0].Key)
+
+	result, err := client.SetIntParam(ctx, vm.TxGasLimitKey, 1.6e9)
+	assert.NoError(t, err)
+	assert.Equal(t, "Notify", result.Event.Name)
+	value, err := client.GetIntParam(ctx, vm.TxGasLimitKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1.6e9), value)
+	_, err = client.SetIntParam(ctx, vm.TxGasLimitKey, 1)
+	assert.Error(t, err)
+	_, err = client.SetStrParam(ctx, vm.VrfParamsKey, `{"electionEpoch":1}`)
+	assert.NoError(t, err)
+	str, err := client.GetStrParam(ctx, vm.VrfParamsKey)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"electionEpoch":1}`, str)
+	_, err = client.SetIsBlockUseTrieHash(ctx, false)
+	assert.NoError(t, err)
+	flag, err := client.GetIsBlockUseTrieHash(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), flag)
+}
@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/log"
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rpc"
+)
+
+// 节点不支持订阅时默认的轮询间隔
+var sysConfigPollInterval = 2 * time.Second
+
+// SysConfigEvent 一个系统参数的变更，Config 为变更后的快照，Events 为触发刷新的合约事件
+type SysConfigEvent struct {
+	SysConfigChange
+	Config SysConfig  `json:"config"`
+	Events []SysEvent `json:"events"`
+}
+
+// SysConfigWatcher 监听参数管理合约的事件，保持最新的系统参数快照并通知参数的变更
+// 节点支持订阅时订阅合约的日志，否则每隔 interval 轮询一次
+type SysConfigWatcher struct {
+	client   SysConfigClient
+	interval time.Duration
+
+	mu        sync.Mutex
+	config    *SysConfig
+	lastBlock uint64
+	synced    bool
+	handlers  []func(SysConfigEvent)
+	subs      map[int]*sysConfigSub
+	nextSub   int
+}
+
+type sysConfigSub struct {
+	ch   chan SysConfigEvent
+	done chan struct{}
+}
+
+// interval 不大于 0 时使用默认的轮询间隔
+func NewSysConfigWatcher(sysConfigClient SysConfigClient, interval time.Duration) *SysConfigWatcher {
+	if interval <= 0 {
+		interval = sysConfigPollInterval
+	}
+	return &SysConfigWatcher{
+		client:   sysConfigClient,
+		interval: interval,
+		subs:     make(map[int]*sysConfigSub),
+	}
+}
+
+// Config 返回当前的参数快照，还没有同步过时返回 false
+func (w *SysConfigWatcher) Config() (SysConfig, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.config == nil {
+		return SysConfig{}, false
+	}
+	return *w.config, true
+}
+
+// OnChange 注册参数变更的回调，回调在同步的 goroutine 中按顺序执行
+func (w *SysConfigWatcher) OnChange(handler func(SysConfigEvent)) {
+	w.mu.Lock()
+	w.handlers = append(w.handlers, handler)
+	w.mu.Unlock()
+}
+
+// Subscribe 返回接收参数变更的 channel 和取消订阅的函数
+// channel 满时同步会等待，取消订阅后 channel 不会被关闭
+func (w *SysConfigWatcher) Subscribe(buffer int) (<-chan SysConfigEvent, func()) {
+	sub := &sysConfigSub{ch: make(chan SysConfigEvent, buffer), done: make(chan struct{})}
+	w.mu.Lock()
+	id := w.nextSub
+	w.nextSub++
+	w.subs[id] = sub
+	w.mu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			w.mu.Lock()
+			delete(w.subs, id)
+			w.mu.Unlock()
+			close(sub.done)
+		})
+	}
+}
+
+// Refresh 查询所有的系统参数，与之前的快照对比后通知变更
+func (w *SysConfigWatcher) Refresh(ctx context.Context) error {
+	return w.refresh(ctx, nil)
+}
+
+func (w *SysConfigWatcher) refresh(ctx context.Context, events []SysEvent) error {
+	config, err := w.client.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	old := w.config
+	w.config = config
+	handlers := append([]func(SysConfigEvent){}, w.handlers...)
+	subs := make([]*sysConfigSub, 0, len(w.subs))
+	for _, sub := range w.subs {
+		subs = append(subs, sub)
+	}
+	w.mu.Unlock()
+	if old == nil {
+		return nil
+	}
+
+	for _, change := range old.Diff(config) {
+		event := SysConfigEvent{SysConfigChange: change, Config: *config, Events: events}
+		for _, handler := range handlers {
+			handler(event)
+		}
+		for _, sub := range subs {
+			select {
+			case sub.ch <- event:
+			case <-sub.done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// HandleLogs 解析参数管理合约的事件，有成功修改参数的事件时刷新快照
+func (w *SysConfigWatcher) HandleLogs(ctx context.Context, logs []*packet.Log) error {
+	var paramLogs packet.RecptLogs
+	for _, eLog := range logs {
+		if strings.EqualFold(eLog.Address, precompile.ParameterManagementAddress) {
+			paramLogs = append(paramLogs, eLog)
+		}
+	}
+	sysEvents, err := ParseSysEvents(&packet.Receipt{Logs: paramLogs}, w.client.ContractContent.GetEvents())
+	if err != nil {
+		return err
+	}
+	var events []SysEvent
+	for _, event := range sysEvents {
+		if event.Code == 0 {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return w.refresh(ctx, events)
+}
+
+// Sync 查询上次同步之后参数管理合约的事件并刷新快照
+// 第一次调用查询所有的系统参数并记录当前的区块高度
+func (w *SysConfigWatcher) Sync(ctx context.Context) error {
+	head, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	from, synced := w.lastBlock+1, w.synced
+	w.mu.Unlock()
+	if !synced {
+		if err := w.Refresh(ctx); err != nil {
+			return err
+		}
+		w.setLastBlock(head)
+		return nil
+	}
+	if head < from {
+		return nil
+	}
+
+	logs, err := w.client.GetLogs(ctx, FilterQuery{
+		FromBlock: hexutil.EncodeUint64(from),
+		ToBlock:   hexutil.EncodeUint64(head),
+		Addresses: []string{precompile.ParameterManagementAddress},
+	})
+	if err != nil {
+		return err
+	}
+	if err := w.HandleLogs(ctx, logs); err != nil {
+		return err
+	}
+	w.setLastBlock(head)
+	return nil
+}
+
+// 已经同步到更高的区块时不回退
+func (w *SysConfigWatcher) setLastBlock(number uint64) {
+	w.mu.Lock()
+	if number > w.lastBlock {
+		w.lastBlock = number
+	}
+	w.synced = true
+	w.mu.Unlock()
+}
+
+// Watch 同步一次后订阅参数管理合约的日志，订阅成功后再同步一次补上中间的区块
+// 节点不支持订阅或者订阅中断时改为轮询，直到 ctx 结束
+func (w *SysConfigWatcher) Watch(ctx context.Context) error {
+	if err := w.Sync(ctx); err != nil {
+		return err
+	}
+	err := w.watchLogs(ctx)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if err != rpc.ErrNotificationsUnsupported {
+		log.Error(fmt.Sprintf("subscribe the system config logs error: %v, fall back to polling", err))
+	}
+	return w.poll(ctx)
+}
+
+func (w *SysConfigWatcher) watchLogs(ctx context.Context) error {
+	logs := make(chan *packet.Log, 16)
+	sub, err := w.client.RpcClient.EthSubscribe(ctx, logs, "logs", FilterQuery{
+		Addresses: []string{precompile.ParameterManagementAddress},
+	})
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+	// 补上同步之后、订阅生效之前的区块中的事件
+	if err := w.Sync(ctx); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case eLog := <-logs:
+			if err := w.handleSubscribedLog(ctx, eLog); err != nil {
+				log.Error(fmt.Sprintf("handle the system config log error: %v", err))
+			}
+		}
+	}
+}
+
+// handleSubscribedLog 处理订阅到的日志并记录日志所在的区块，订阅中断后轮询时不会重复处理这些区块
+func (w *SysConfigWatcher) handleSubscribedLog(ctx context.Context, eLog *packet.Log) error {
+	if err := w.HandleLogs(ctx, []*packet.Log{eLog}); err != nil {
+		return err
+	}
+	if number, err := hexutil.DecodeUint64(eLog.BlockNumber); err == nil {
+		w.setLastBlock(number)
+	}
+	return nil
+}
+
+// 轮询出错时保留当前的快照，下次同步时重新查询这段区块的事件
+func (w *SysConfigWatcher) poll(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := w.Sync(ctx); err != nil {
+				log.Error(fmt.Sprintf("sync the system config error: %v", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/venachain/rpc"
+	"github.com/Venachain/client-sdk-go/venachain/vm"
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockSysConfigWatcher(t *testing.T) (*mockParamManager, *SysConfigClient, func()) {
+	mock := &mockParamManager{
+		params: map[string]string{
+			vm.TxGasLimitKey:                      "1500000000",
+			vm.BlockGasLimitKey:                   "10000000000",
+			vm.IsTxUseGasKey:                      "0",
+			vm.IsApproveDeployedContractKey:       "0",
+			vm.IsCheckContractDeployPermissionKey: "0",
+			vm.IsProduceEmptyBlockKey:             "0",
+			vm.IsBlockUseTrieHashKey:              "0",
+		},
+	}
+	server, url := newMockNode(t, mock.handlers(t))
+	client, err := NewSysConfigClientWithKey(context.Background(), url, newTestKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return mock, client, func() {
+		client.RpcClient.Close()
+		server.Close()
+	}
+}
+
+func TestSysConfigWatcher_Sync(t *testing.T) {
+	mock, client, closeFn := newMockSysConfigWatcher(t)
+	defer closeFn()
+	ctx := context.Background()
+
+	watcher := NewSysConfigWatcher(*client, 0)
+	_, ok := watcher.Config()
+	assert.False(t, ok)
+	assert.NoError(t, watcher.Sync(ctx))
+	config, ok := watcher.Config()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1.5e9), config.TxGasLimit)
+
+	var (
+		mu      sync.Mutex
+		changes []SysConfigEvent
+	)
+	watcher.OnChange(func(event SysConfigEvent) {
+		mu.Lock()
+		changes = append(changes, event)
+		mu.Unlock()
+	})
+	ch, cancel := watcher.Subscribe(4)
+
+	_, err := client.SetIsBlockUseTrieHash(ctx, false)
+	assert.NoError(t, err)
+	desired := config
+	desired.TxGasLimit, desired.IsTxUseGas = 1.9e9, true
+	_, err = client.ApplySysConfig(ctx, &desired)
+	assert.NoError(t, err)
+	// 失败的修改不会刷新快照
+	desired.GasContractName = "unknown"
+	_, err = client.ApplySysConfig(ctx, &desired)
+	assert.Error(t, err)
+	mock.sent()
+
+	assert.NoError(t, watcher.Sync(ctx))
+	config, _ = watcher.Config()
+	assert.Equal(t, uint64(1.9e9), config.TxGasLimit)
+	assert.True(t, config.IsTxUseGas)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, SysConfigChange{Key: vm.TxGasLimitKey, Old: "1500000000", New: "1900000000"}, changes[0].SysConfigChange)
+	assert.Equal(t, config, changes[0].Config)
+	assert.Len(t, changes[0].Events, 3)
+	assert.Equal(t, vm.TxGasLimitKey, (<-ch).Key)
+	assert.Equal(t, SysConfigChange{Key: vm.IsTxUseGasKey, Old: "0", New: "1"}, (<-ch).SysConfigChange)
+
+	// 只有失败事件的区块不会重新查询参数
+	_, err = client.ApplySysConfig(ctx, &desired)
+	assert.Error(t, err)
+	mock.sent()
+	assert.NoError(t, watcher.Sync(ctx))
+	assert.Empty(t, mock.sent())
+
+	// 取消订阅后不再发送，也不会阻塞同步
+	cancel()
+	cancel()
+	assert.NoError(t, watcher.HandleLogs(ctx, []*packet.Log{{Address: "0x1000000000000000000000000000000000000001"}}))
+	_, err = client.SetIntParam(ctx, vm.TxGasLimitKey, 1.6e9)
+	assert.NoError(t, err)
+	assert.NoError(t, watcher.Sync(ctx))
+	assert.Len(t, changes, 3)
+	assert.Empty(t, ch)
+}
+
+func TestSysConfigWatcher_SubscribedLog(t *testing.T) {
+	mock, client, closeFn := newMockSysConfigWatcher(t)
+	defer closeFn()
+	ctx := context.Background()
+
+	watcher := NewSysConfigWatcher(*client, 0)
+	assert.NoError(t, watcher.Sync(ctx))
+	changes := 0
+	watcher.OnChange(func(event SysConfigEvent) { changes++ })
+
+	_, err := client.SetIntParam(ctx, vm.BlockGasLimitKey, 1.2e10)
+	assert.NoError(t, err)
+	// 订阅到的日志带有所在的区块
+	mock.mu.Lock()
+	eLog := *mock.logs[len(mock.logs)-1]
+	mock.mu.Unlock()
+	assert.NoError(t, watcher.handleSubscribedLog(ctx, &eLog))
+	assert.Equal(t, 1, changes)
+
+	// 订阅中断后轮询时不会再次处理已经订阅到的区块
+	mock.mu.Lock()
+	mock.calls = nil
+	mock.mu.Unlock()
+	assert.NoError(t, watcher.Sync(ctx))
+	assert.Equal(t, 1, changes)
+	assert.Empty(t, mock.calls)
+}
+
+func TestSysConfigWatcher_Watch(t *testing.T) {
+	_, client, closeFn := newMockSysConfigWatcher(t)
+	defer closeFn()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// http 连接不支持订阅，改为轮询
+	watcher := NewSysConfigWatcher(*client, 10*time.Millisecond)
+	ch, _ := watcher.Subscribe(1)
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Watch(ctx)
+	}()
+	assert.Eventually(t, func() bool {
+		_, ok := watcher.Config()
+		return ok
+	}, time.Second, 5*time.Millisecond)
+
+	_, err := client.SetIntParam(ctx, vm.BlockGasLimitKey, 1.2e10)
+	assert.NoError(t, err)
+	select {
+	case event := <-ch:
+		assert.Equal(t, SysConfigChange{Key: vm.BlockGasLimitKey, Old: "10000000000", New: "12000000000"}, event.SysConfigChange)
+		assert.Equal(t, "Notify", event.Events[0].Name)
+	case <-time.After(time.Second):
+		t.Fatal("no change notification")
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+// 通过 websocket 提供参数管理合约的查询和日志订阅，onSubscribe 在订阅生效前执行
+// rpc 服务只注册导出的类型
+type MockWsParamManager struct {
+	handlers    map[string]mockHandler
+	onSubscribe func()
+}
+
+func (s *MockWsParamManager) BlockNumber() interface{} {
+	return s.handlers["eth_blockNumber"](nil)
+}
+
+func (s *MockWsParamManager) GetLogs(query json.RawMessage) interface{} {
+	return s.handlers["eth_getLogs"]([]json.RawMessage{query})
+}
+
+func (s *MockWsParamManager) Call(args json.RawMessage, block json.RawMessage) interface{} {
+	return s.handlers["eth_call"]([]json.RawMessage{args, block})
+}
+
+func (s *MockWsParamManager) Logs(ctx context.Context, query json.RawMessage) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	s.onSubscribe()
+	return notifier.CreateSubscription(), nil
+}
+
+func TestSysConfigWatcher_WatchBackfill(t *testing.T) {
+	mock, client, closeFn := newMockSysConfigWatcher(t)
+	defer closeFn()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 同步之后、订阅生效之前修改参数
+	service := &MockWsParamManager{handlers: mock.handlers(t), onSubscribe: func() {
+		_, err := client.SetIntParam(context.Background(), vm.BlockGasLimitKey, 1.2e10)
+		assert.NoError(t, err)
+	}}
+	server := rpc.NewServer()
+	assert.NoError(t, server.RegisterName("eth", service))
+	defer server.Stop()
+	wsServer := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	defer wsServer.Close()
+	wsClient, err := rpc.DialWebsocket(ctx, "ws"+strings.TrimPrefix(wsServer.URL, "http"), "")
+	assert.NoError(t, err)
+	watchClient := *client
+	watchClient.ContractClient.Client = &Client{RpcClient: wsClient, Key: client.Key}
+
+	watcher := NewSysConfigWatcher(watchClient, time.Hour)
+	ch, _ := watcher.Subscribe(1)
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Watch(ctx)
+	}()
+	select {
+	case event := <-ch:
+		assert.Equal(t, SysConfigChange{Key: vm.BlockGasLimitKey, Old: "10000000000", New: "12000000000"}, event.SysConfigChange)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the change before subscribing is missed")
+	}
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
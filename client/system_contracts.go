@@ -0,0 +1,277 @@
+package client
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	common_venachain "github.com/Venachain/client-sdk-go/venachain/common"
+)
+
+// SystemContract 系统合约的描述，只包含事件的 abi（如 cns 调用事件）没有合约地址
+type SystemContract struct {
+	Name    string
+	Address common_venachain.Address
+	Content packet.ContractContent
+	// 只有事件的 abi，事件可以由任意地址产生
+	EventOnly bool
+}
+
+// Event 按照名称查找合约的事件
+func (contract *SystemContract) Event(name string) (*packet.FuncDesc, bool) {
+	for _, event := range contract.Content.GetEvents() {
+		if event.Name == name {
+			return event, true
+		}
+	}
+	return nil, false
+}
+
+// SystemContractRegistry 按照地址或者名称查找系统合约的 abi，并解析发送给系统合约的交易和系统合约的日志
+type SystemContractRegistry struct {
+	mu        sync.RWMutex
+	byAddress map[common_venachain.Address]*SystemContract
+	byName    map[string]*SystemContract
+	events    []*SystemContract
+}
+
+// SystemContracts 默认的系统合约注册表，包含 precompile.List 中的所有合约
+var SystemContracts = NewSystemContractRegistry()
+
+// NewSystemContractRegistry 创建包含 precompile.List 中所有系统合约的注册表
+// 合约名称为 abi 文件名，如 userManager、cnsInvokeEvent
+func NewSystemContractRegistry() *SystemContractRegistry {
+	r := &SystemContractRegistry{
+		byAddress: make(map[common_venachain.Address]*SystemContract),
+		byName:    make(map[string]*SystemContract),
+	}
+	for key, abiPath := range precompile.List {
+		abiBytes, err := precompile.GetContractByte(abiPath)
+		if err != nil {
+			continue
+		}
+		address := ""
+		if packet.IsMatch(key, "address") {
+			address = key
+		}
+		_, _ = r.Register(systemContractName(abiPath), address, abiBytes)
+	}
+	return r
+}
+
+func systemContractName(abiPath string) string {
+	name := path.Base(abiPath)
+	for _, suffix := range []string{".cpp.abi.json", ".abi.json", ".json"} {
+		if strings.HasSuffix(name, suffix) {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name
+}
+
+// Register 注册系统合约，address 为空时只注册 abi 中的事件
+// 已经注册的名称或者地址会被替换
+func (r *SystemContractRegistry) Register(name string, address string, abiBytes []byte) (*SystemContract, error) {
+	if name == "" {
+		return nil, fmt.Errorf("the name of the system contract is empty")
+	}
+	if address != "" && !packet.IsMatch(address, "address") {
+		return nil, fmt.Errorf("invalid address %s of the system contract %s", address, name)
+	}
+	content, err := packet.ParseAbiFromJson(abiBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse the abi of the system contract %s error: %v", name, err)
+	}
+	contract := &SystemContract{Name: name, Content: content, EventOnly: address == ""}
+	if !contract.EventOnly {
+		contract.Address = common_venachain.HexToAddress(address)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.byName[name]; ok {
+		r.remove(old)
+	}
+	if !contract.EventOnly {
+		if old, ok := r.byAddress[contract.Address]; ok {
+			r.remove(old)
+		}
+		r.byAddress[contract.Address] = contract
+	} else {
+		r.events = append(r.events, contract)
+	}
+	r.byName[name] = contract
+	return contract, nil
+}
+
+// Unregister 删除注册的系统合约，返回合约是否存在
+func (r *SystemContractRegistry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	contract, ok := r.byName[name]
+	if ok {
+		r.remove(contract)
+	}
+	return ok
+}
+
+func (r *SystemContractRegistry) remove(contract *SystemContract) {
+	delete(r.byName, contract.Name)
+	if !contract.EventOnly {
+		delete(r.byAddress, contract.Address)
+		return
+	}
+	for i, c := range r.events {
+		if c == contract {
+			r.events = append(r.events[:i], r.events[i+1:]...)
+			break
+		}
+	}
+}
+
+// ByAddress 按照地址查找系统合约
+func (r *SystemContractRegistry) ByAddress(address string) (*SystemContract, bool) {
+	if !packet.IsMatch(address, "address") {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	contract, ok := r.byAddress[common_venachain.HexToAddress(address)]
+	return contract, ok
+}
+
+// ByName 按照名称查找系统合约
+func (r *SystemContractRegistry) ByName(name string) (*SystemContract, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	contract, ok := r.byName[name]
+	return contract, ok
+}
+
+// List 返回所有注册的系统合约，按照名称排序
+func (r *SystemContractRegistry) List() []*SystemContract {
+	r.mu.RLock()
+	contracts := make([]*SystemContract, 0, len(r.byName))
+	for _, contract := range r.byName {
+		contracts = append(contracts, contract)
+	}
+	r.mu.RUnlock()
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].Name < contracts[j].Name
+	})
+	return contracts
+}
+
+// Contents 返回所有有地址的系统合约的 abi，priorAddr 的合约排在最前面
+func (r *SystemContractRegistry) Contents(priorAddr string) []packet.ContractContent {
+	var contents []packet.ContractContent
+	if prior, ok := r.ByAddress(priorAddr); ok {
+		contents = append(contents, prior.Content)
+	}
+	for _, contract := range r.List() {
+		if !contract.EventOnly && !strings.EqualFold(contract.Address.Hex(), priorAddr) {
+			contents = append(contents, contract.Content)
+		}
+	}
+	return contents
+}
+
+// DecodeCall 解析发送给系统合约的交易数据
+func (r *SystemContractRegistry) DecodeCall(to string, data []byte) (*SystemContract, *packet.DecodedCall, error) {
+	contract, ok := r.ByAddress(to)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not a system contract", to)
+	}
+	address := contract.Address
+	call, err := packet.DecodeCallData(data, &address, contract.Content)
+	return contract, call, err
+}
+
+// DecodeRawTransaction 解析原始交易，交易的 data 按照注册的系统合约的 abi 解析
+func (r *SystemContractRegistry) DecodeRawTransaction(rawTx string, contents ...packet.ContractContent) (*DecodedTx, error) {
+	return decodeRawTransaction(rawTx, r, contents...)
+}
+
+// DecodedLog 解析后的系统合约日志，Contract 为产生事件的系统合约名称
+type DecodedLog struct {
+	Contract string                `json:"contract"`
+	Address  string                `json:"address"`
+	Event    string                `json:"event"`
+	Params   []packet.DecodedParam `json:"params"`
+}
+
+// SysEvent 参数为 (code, msg) 的事件转换为 SysEvent
+func (l *DecodedLog) SysEvent() (SysEvent, bool) {
+	if len(l.Params) < 2 {
+		return SysEvent{}, false
+	}
+	code, ok := sysEventCode(l.Params[0].Value)
+	if !ok {
+		return SysEvent{}, false
+	}
+	msg, ok := l.Params[1].Value.(string)
+	if !ok {
+		return SysEvent{}, false
+	}
+	event := SysEvent{Name: l.Event, Code: code, Msg: msg}
+	if len(l.Params) > 2 {
+		event.Detail = fmt.Sprint(l.Params[2].Value)
+	}
+	return event, true
+}
+
+// DecodeLog 解析系统合约的日志，先按照日志地址上的合约查找事件，再查找只有事件的 abi
+// 不是系统合约的事件时返回 false
+func (r *SystemContractRegistry) DecodeLog(eLog *packet.Log) (*DecodedLog, bool, error) {
+	if len(eLog.Topics) == 0 {
+		return nil, false, nil
+	}
+	var candidates []*SystemContract
+	if contract, ok := r.ByAddress(eLog.Address); ok {
+		candidates = append(candidates, contract)
+	}
+	r.mu.RLock()
+	candidates = append(candidates, r.events...)
+	r.mu.RUnlock()
+
+	for _, contract := range candidates {
+		for _, event := range contract.Content.GetEvents() {
+			if !strings.EqualFold(packet.WasmEventTopic(event.Name), eLog.Topics[0]) {
+				continue
+			}
+			types := make([]string, len(event.Inputs))
+			for i, input := range event.Inputs {
+				types[i] = input.Type
+			}
+			values, err := packet.WasmEventData(eLog, types)
+			if err != nil {
+				return nil, true, fmt.Errorf("decode the event %s of %s error: %v", event.Name, contract.Name, err)
+			}
+			decoded := &DecodedLog{Contract: contract.Name, Address: eLog.Address, Event: event.Name}
+			for i, input := range event.Inputs {
+				decoded.Params = append(decoded.Params, packet.DecodedParam{Name: input.Name, Type: input.Type, Value: values[i]})
+			}
+			return decoded, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// DecodeReceipt 解析回执中所有系统合约的日志，跳过其他合约的日志
+func (r *SystemContractRegistry) DecodeReceipt(receipt *packet.Receipt) ([]*DecodedLog, error) {
+	var logs []*DecodedLog
+	for _, eLog := range receipt.Logs {
+		decoded, ok, err := r.DecodeLog(eLog)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			logs = append(logs, decoded)
+		}
+	}
+	return logs, nil
+}
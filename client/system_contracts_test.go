@@ -0,0 +1,158 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPrecompileAbi = `[{"name":"hello","type":"function","constant":false,
+"inputs":[{"name":"name","type":"string"}],"outputs":[]},
+{"name":"Hello","type":"event","inputs":[{"name":"code","type":"uint64"},{"name":"msg","type":"string"},{"name":"count","type":"uint32"}]}]`
+
+const testPrecompileAddress = "0x0000000000000000000000000000000000000200"
+
+func TestSystemContracts(t *testing.T) {
+	assert.Len(t, SystemContracts.List(), len(precompile.List))
+	contract, ok := SystemContracts.ByName("paramManager")
+	assert.True(t, ok)
+	assert.Equal(t, precompile.ParameterManagementAddress, contract.Address.Hex())
+	_, ok = contract.Event("Notify")
+	assert.True(t, ok)
+	contract, ok = SystemContracts.ByAddress("0x1000000000000000000000000000000000000001")
+	assert.True(t, ok)
+	assert.Equal(t, "userManager", contract.Name)
+	contract, ok = SystemContracts.ByName("cnsInvokeEvent")
+	assert.True(t, ok)
+	assert.True(t, contract.EventOnly)
+	_, ok = SystemContracts.ByAddress(testPrecompileAddress)
+	assert.False(t, ok)
+
+	// 发送给系统合约的交易
+	key := newTestKey(t)
+	stx, err := SignUnsignedTx(newTestUnsignedTx(t, key), key)
+	assert.NoError(t, err)
+	decoded, err := SystemContracts.DecodeRawTransaction(stx.RawTx)
+	assert.NoError(t, err)
+	assert.Equal(t, "saveEvidence", decoded.Call.Method)
+	contract, call, err := SystemContracts.DecodeCall(decoded.To.Hex(), decoded.Tx.Data())
+	assert.NoError(t, err)
+	assert.Equal(t, "evidenceManager", contract.Name)
+	assert.Equal(t, "key", call.Params[0].Value)
+	_, _, err = SystemContracts.DecodeCall(testPrecompileAddress, decoded.Tx.Data())
+	assert.Error(t, err)
+}
+
+func TestSystemContracts_DecodeLog(t *testing.T) {
+	other := "0x6988decc03a2d38888534ad0b4a33a267b34807d"
+	receipt := &packet.Receipt{Logs: packet.RecptLogs{
+		testSysLog(precompile.ParameterManagementAddress, "TxGasLimit", uint64(0), "success"),
+		// cns 调用、cns 注册和无权限部署的事件可以由任意合约产生
+		testSysLog(other, "CnsInvoke", uint64(1), "contract not found"),
+		testSysLog(other, "[CNS] Notify", uint64(0), "register wxbc"),
+		testSysLog(other, "contract permission", "the contract deployment is denied"),
+		testSysLog(other, "Transfer", uint64(0), "ok"),
+	}}
+	logs, err := SystemContracts.DecodeReceipt(receipt)
+	assert.NoError(t, err)
+	assert.Len(t, logs, 4)
+	assert.Equal(t, &DecodedLog{
+		Contract: "paramManager",
+		Address:  precompile.ParameterManagementAddress,
+		Event:    "TxGasLimit",
+		Params: []packet.DecodedParam{
+			{Type: "uint32", Value: uint32(0)},
+			{Type: "string", Value: "success"},
+		},
+	}, logs[0])
+	event, ok := logs[0].SysEvent()
+	assert.True(t, ok)
+	assert.Equal(t, SysEvent{Name: "TxGasLimit", Msg: "success"}, event)
+	assert.Equal(t, "cnsInvokeEvent", logs[1].Contract)
+	event, ok = logs[1].SysEvent()
+	assert.True(t, ok)
+	assert.Equal(t, SysEvent{Name: "CnsInvoke", Code: 1, Msg: "contract not found"}, event)
+	assert.Equal(t, "cnsInitRegEvent", logs[2].Contract)
+	assert.Equal(t, "permissionDeniedEvent", logs[3].Contract)
+	assert.Equal(t, "the contract deployment is denied", logs[3].Params[0].Value)
+	_, ok = logs[3].SysEvent()
+	assert.False(t, ok)
+
+	// 用户管理合约的 code 为 uint32，代理合约的 code 为 int32
+	for _, eLog := range []*packet.Log{
+		testSysLog("0x1000000000000000000000000000000000000001", "addUser", uint32(1), "no permission"),
+		testSysLog(precompile.ContractProxyAddress, "execute", uint32(1), "no permission"),
+	} {
+		decodedLog, ok, err := SystemContracts.DecodeLog(eLog)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		event, ok := decodedLog.SysEvent()
+		assert.True(t, ok, decodedLog.Contract)
+		assert.Equal(t, uint64(1), event.Code)
+	}
+
+	// 事件数据与 abi 不符
+	_, ok, err = SystemContracts.DecodeLog(testSysLog(other, "CnsInvoke", "bad"))
+	assert.True(t, ok)
+	assert.Error(t, err)
+}
+
+func TestSystemContracts_Register(t *testing.T) {
+	registry := NewSystemContractRegistry()
+	_, err := registry.Register("myPrecompile", "0x200", []byte(testPrecompileAbi))
+	assert.Error(t, err)
+	_, err = registry.Register("myPrecompile", testPrecompileAddress, []byte("{"))
+	assert.Error(t, err)
+	contract, err := registry.Register("myPrecompile", testPrecompileAddress, []byte(testPrecompileAbi))
+	assert.NoError(t, err)
+	found, ok := registry.ByAddress(testPrecompileAddress)
+	assert.True(t, ok)
+	assert.Equal(t, contract, found)
+	assert.Equal(t, contract.Content, registry.Contents(testPrecompileAddress)[0])
+
+	// 运行时注册的合约可以解析交易和日志
+	key := newTestKey(t)
+	executeContract := NewExecuteContract(testPrecompileAddress, "wasm", "hello", []string{"world"})
+	txparam, _, err := getTxparamWithContent(*executeContract, contract.Content, key.Address.Hex())
+	assert.NoError(t, err)
+	rawTx, err := txparam.GetSignedTx(key)
+	assert.NoError(t, err)
+	decoded, err := registry.DecodeRawTransaction(rawTx)
+	assert.NoError(t, err)
+	assert.Empty(t, decoded.CallErr)
+	assert.Equal(t, "hello", decoded.Call.Method)
+	assert.Equal(t, "world", decoded.Call.Params[0].Value)
+	decoded, err = DecodeRawTransaction(rawTx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, decoded.CallErr)
+
+	decodedLog, ok, err := registry.DecodeLog(testSysLog(testPrecompileAddress, "Hello", uint64(0), "hi", uint32(3)))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	event, _ := decodedLog.SysEvent()
+	assert.Equal(t, SysEvent{Name: "Hello", Msg: "hi", Detail: "3"}, event)
+	assert.Equal(t, "count", decodedLog.Params[2].Name)
+
+	// 同一个地址重新注册时替换原来的合约
+	_, err = registry.Register("myPrecompileV2", testPrecompileAddress, []byte(testPrecompileAbi))
+	assert.NoError(t, err)
+	_, ok = registry.ByName("myPrecompile")
+	assert.False(t, ok)
+	assert.True(t, registry.Unregister("myPrecompileV2"))
+	assert.False(t, registry.Unregister("myPrecompileV2"))
+	_, ok = registry.ByAddress(testPrecompileAddress)
+	assert.False(t, ok)
+
+	// 注册到默认的注册表后，客户端可以按照地址获得 abi
+	_, err = GetContractByContractAddress(testPrecompileAddress)
+	assert.Error(t, err)
+	_, err = SystemContracts.Register("myPrecompile", testPrecompileAddress, []byte(testPrecompileAbi))
+	assert.NoError(t, err)
+	defer SystemContracts.Unregister("myPrecompile")
+	content, err := GetContractByContractAddress(testPrecompileAddress)
+	assert.NoError(t, err)
+	_, err = content.GetFuncFromAbi("hello")
+	assert.NoError(t, err)
+}
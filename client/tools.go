@@ -40,17 +40,23 @@ func GetTxparam(executeContract ExecuteContract, contractAbiPath string, account
 	if err != nil {
 		return nil, err
 	}
+	txparam, _, err := getTxparamWithContent(executeContract, contractContent, account)
+	return txparam, err
+}
+
+// 通过合约内容生成交易参数，同时返回调用方法的 abi
+func getTxparamWithContent(executeContract ExecuteContract, contractContent packet.ContractContent, account string) (*common.TxParams, *packet.FuncDesc, error) {
 	cns, to, err := packet.CnsParse(executeContract.Address)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	methodAbi, err := contractContent.GetFuncFromAbi(executeContract.Func)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	funcArgs, err := methodAbi.StringToArgs(executeContract.FuncParams)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	data := packet.NewData(funcArgs, methodAbi)
 	dataGenerator := packet.NewContractDataGen(data, contractContent, cns.TxType)
@@ -63,7 +69,7 @@ func GetTxparam(executeContract ExecuteContract, contractAbiPath string, account
 	userAccount := vena_common.HexToAddress(account)
 	txparam, err := dataGenerator.MakeTxparamForContract(&userAccount, &dataGenerator.To)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return txparam, nil
+	return txparam, methodAbi, nil
 }
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	"github.com/Venachain/client-sdk-go/types"
+	vena_common "github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+)
+
+// DecodedTx 解析后的原始交易
+type DecodedTx struct {
+	Tx      *types.Transaction   `json:"-"`
+	Hash    string               `json:"hash"`
+	From    vena_common.Address  `json:"from"`
+	To      *vena_common.Address `json:"to"`
+	Nonce   uint64               `json:"nonce"`
+	Gas     uint64               `json:"gas"`
+	Value   *big.Int             `json:"value"`
+	ChainID *big.Int             `json:"chainId"` // 未开启重放保护的交易为 0
+	// 国密模式下交易 payload 前携带的 SM2 公钥
+	PubKey string `json:"pubKey,omitempty"`
+	// 国密模式下签名与 payload 中的公钥不符时无法确认发送者，此时 From 为空
+	SenderErr string              `json:"senderErr,omitempty"`
+	Call      *packet.DecodedCall `json:"call,omitempty"`
+	// 找不到 abi 等原因导致的 data 解析错误，不影响交易本身的解析
+	CallErr string `json:"callErr,omitempty"`
+}
+
+// 解析已签名的原始交易，恢复发送者和 chain id，并解析调用的合约方法
+// data 先按照传入的 contents 解析，再按照 SystemContracts 中系统合约的 abi 解析
+func DecodeRawTransaction(rawTx string, contents ...packet.ContractContent) (*DecodedTx, error) {
+	return decodeRawTransaction(rawTx, SystemContracts, contents...)
+}
+
+func decodeRawTransaction(rawTx string, registry *SystemContractRegistry, contents ...packet.ContractContent) (*DecodedTx, error) {
+	raw, err := hexutil.Decode(rawTx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid raw transaction: %v", err)
+	}
+	tx := new(types.Transaction)
+	if err = rlp.DecodeBytes(raw, tx); err != nil {
+		return nil, fmt.Errorf("decode raw transaction error: %v", err)
+	}
+
+	decoded := &DecodedTx{
+		Tx:      tx,
+		Hash:    tx.Hash().Hex(),
+		To:      tx.To(),
+		Nonce:   tx.Nonce(),
+		Gas:     tx.Gas(),
+		Value:   tx.Value(),
+		ChainID: tx.ChainId(),
+	}
+
+	data := tx.Data()
+	if crypto.IsGM() {
+		// 国密模式下发送者由 payload 前的公钥得出，公钥必须能验证交易的签名
+		if len(data) < crypto.GMPKLength {
+			return nil, fmt.Errorf("invalid sm2 public key in the payload")
+		}
+		decoded.PubKey = hexutil.Encode(data[:crypto.GMPKLength])
+		if decoded.From, err = gmSender(tx); err != nil {
+			decoded.SenderErr = err.Error()
+		}
+		data = data[crypto.GMPKLength:]
+	} else {
+		decoded.From, err = types.Sender(types.MakeSigner(tx), tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	priorAddr := ""
+	if tx.To() != nil {
+		priorAddr = tx.To().String()
+	}
+	contents = append(contents, registry.Contents(priorAddr)...)
+	decoded.Call, err = packet.DecodeCallData(data, tx.To(), contents...)
+	if err != nil {
+		decoded.CallErr = err.Error()
+	}
+	return decoded, nil
+}
+
+// 用 payload 前的 SM2 公钥验证交易签名，验证通过后返回公钥对应的地址
+func gmSender(tx *types.Transaction) (vena_common.Address, error) {
+	pubKey := new(crypto.GMSMPubKey)
+	if err := pubKey.FromBytes(tx.Data()[:crypto.GMPKLength]); err != nil {
+		return vena_common.Address{}, fmt.Errorf("invalid sm2 public key in the payload: %v", err)
+	}
+	_, r, s := tx.RawSignatureValues()
+	sig, err := asn1.Marshal(crypto.SM2Sig{R: r, S: s})
+	if err != nil {
+		return vena_common.Address{}, err
+	}
+	hash := types.MakeSigner(tx).Hash(tx)
+	if ok, err := pubKey.Verify(hash[:], sig); err != nil || !ok {
+		return vena_common.Address{}, errors.New("the signature does not match the sm2 public key in the payload, the sender is unverified")
+	}
+	return pubKey.GetAddress(), nil
+}
+
+// 以便于人工审核的格式输出交易内容
+func (d *DecodedTx) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "hash:     %s\n", d.Hash)
+	if d.SenderErr != "" {
+		fmt.Fprintf(&b, "from:     <unverified: %s>\n", d.SenderErr)
+	} else {
+		fmt.Fprintf(&b, "from:     %s\n", d.From.Hex())
+	}
+	if d.To != nil {
+		fmt.Fprintf(&b, "to:       %s\n", d.To.Hex())
+	} else {
+		fmt.Fprintf(&b, "to:       <contract creation>\n")
+	}
+	fmt.Fprintf(&b, "nonce:    %d\n", d.Nonce)
+	fmt.Fprintf(&b, "gas:      %d\n", d.Gas)
+	fmt.Fprintf(&b, "value:    %v\n", d.Value)
+	fmt.Fprintf(&b, "chainId:  %v\n", d.ChainID)
+	if d.Call != nil {
+		callBytes, _ := json.MarshalIndent(d.Call, "", "\t")
+		fmt.Fprintf(&b, "call:     %s\n", callBytes)
+	}
+	if d.CallErr != "" {
+		fmt.Fprintf(&b, "callErr:  %s\n", d.CallErr)
+	}
+	return b.String()
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/types"
+	vena_common "github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/tjfoc/gmsm/sm2"
+)
+
+const testEvmAbi = `[{"name":"transfer","type":"function","constant":false,
+"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+
+func TestDecodeRawTransaction_Precompiled(t *testing.T) {
+	key := newTestKey(t)
+	stx, err := SignUnsignedTx(newTestUnsignedTx(t, key), key)
+	assert.NoError(t, err)
+
+	decoded, err := DecodeRawTransaction(stx.RawTx)
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, decoded.From)
+	assert.Equal(t, stx.Hash, decoded.Hash)
+	assert.Equal(t, uint64(10), decoded.Nonce)
+	assert.Equal(t, int64(0), decoded.ChainID.Int64())
+	assert.Empty(t, decoded.CallErr)
+	assert.Equal(t, "wasm", decoded.Call.VmType)
+	assert.Equal(t, "saveEvidence", decoded.Call.Method)
+	assert.Equal(t, "key", decoded.Call.Params[0].Value)
+	assert.Equal(t, "value", decoded.Call.Params[1].Value)
+}
+
+func TestDecodeRawTransaction_Cns(t *testing.T) {
+	key := newTestKey(t)
+	contractContent, err := GenContractContent(precompile.EvidenceManagementAddress)
+	assert.NoError(t, err)
+	executeContract := NewExecuteContract("evidence", "wasm", "getEvidence", []string{"key"})
+	txparam, _, err := getTxparamWithContent(*executeContract, contractContent, key.Address.Hex())
+	assert.NoError(t, err)
+	rawTx, err := txparam.GetSignedTx(key)
+	assert.NoError(t, err)
+
+	decoded, err := DecodeRawTransaction(rawTx, contractContent)
+	assert.NoError(t, err)
+	assert.Equal(t, "evidence", decoded.Call.CnsName)
+	assert.Equal(t, "getEvidence", decoded.Call.Method)
+	assert.Equal(t, "key", decoded.Call.Params[0].Value)
+}
+
+func TestDecodeRawTransaction_Evm(t *testing.T) {
+	key := newTestKey(t)
+	contractContent, err := packet.ParseAbiFromJson([]byte(testEvmAbi))
+	assert.NoError(t, err)
+	receiver := "0x6988decc03a2d38888534ad0b4a33a267b34807d"
+	executeContract := NewExecuteContract("0x1000000000000000000000000000000000000099", "evm", "transfer", []string{receiver, "100"})
+	txparam, _, err := getTxparamWithContent(*executeContract, contractContent, key.Address.Hex())
+	assert.NoError(t, err)
+
+	// 使用 EIP155 签名，解析出 chain id
+	data, _ := hexutil.Decode(txparam.Data)
+	tx := types.NewTransaction(1, *txparam.To, big.NewInt(0), 0, big.NewInt(0), data)
+	tx, err = types.SignTx(tx, types.NewEIP155Signer(big.NewInt(300)), key.PrivateKey)
+	assert.NoError(t, err)
+	raw, err := rlp.EncodeToBytes(tx)
+	assert.NoError(t, err)
+
+	decoded, err := DecodeRawTransaction(hexutil.Encode(raw), contractContent)
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, decoded.From)
+	assert.Equal(t, int64(300), decoded.ChainID.Int64())
+	assert.Equal(t, "evm", decoded.Call.VmType)
+	assert.Equal(t, "transfer", decoded.Call.Method)
+	assert.Equal(t, vena_common.HexToAddress(receiver), decoded.Call.Params[0].Value)
+	assert.Equal(t, big.NewInt(100), decoded.Call.Params[1].Value)
+
+	// 没有 abi 时无法解析 data，但交易本身可以解析
+	decoded, err = DecodeRawTransaction(hexutil.Encode(raw))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, decoded.CallErr)
+}
+
+// 国密交易的 payload 前携带 pubKey，签名由 key 产生
+func newTestGMTx(t *testing.T, key *sm2.PrivateKey, pubKey *sm2.PublicKey) *types.Transaction {
+	pub := crypto.NewGMSMPubKey(pubKey.X, pubKey.Y)
+	pubBytes, err := pub.Bytes()
+	assert.NoError(t, err)
+	tx := types.NewTransaction(1, vena_common.HexToAddress(precompile.EvidenceManagementAddress), big.NewInt(0), 0, big.NewInt(0), append(pubBytes, 0x01))
+	hash := types.HomesteadSigner{}.Hash(tx)
+	der, err := crypto.NewGMSMPrivKey(crypto.NewGMSMPubKey(key.X, key.Y), key.D).Sign(hash[:])
+	assert.NoError(t, err)
+	var sm2Sig crypto.SM2Sig
+	_, err = asn1.Unmarshal(der, &sm2Sig)
+	assert.NoError(t, err)
+	sig := make([]byte, 65)
+	sm2Sig.R.FillBytes(sig[:32])
+	sm2Sig.S.FillBytes(sig[32:64])
+	tx, err = tx.WithSignature(types.HomesteadSigner{}, sig)
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestGMSender(t *testing.T) {
+	key, err := sm2.GenerateKey()
+	assert.NoError(t, err)
+	other, err := sm2.GenerateKey()
+	assert.NoError(t, err)
+
+	from, err := gmSender(newTestGMTx(t, key, &key.PublicKey))
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.NewGMSMPubKey(key.X, key.Y).GetAddress(), from)
+
+	// payload 中放入其他人的公钥
+	_, err = gmSender(newTestGMTx(t, key, &other.PublicKey))
+	assert.Error(t, err)
+
+	// 签名后修改交易
+	tx := newTestGMTx(t, key, &key.PublicKey)
+	_, r, s := tx.RawSignatureValues()
+	tampered := types.NewTransaction(tx.Nonce(), *tx.To(), big.NewInt(100), tx.Gas(), tx.GasPrice(), tx.Data())
+	sig := make([]byte, 65)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	tampered, err = tampered.WithSignature(types.HomesteadSigner{}, sig)
+	assert.NoError(t, err)
+	_, err = gmSender(tampered)
+	assert.Error(t, err)
+}
@@ -3,10 +3,12 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"github.com/Venachain/client-sdk-go/types"
+	"io"
+	"math/big"
 
 	"github.com/Venachain/client-sdk-go/packet"
 	"github.com/Venachain/client-sdk-go/precompiled/syscontracts"
+	"github.com/Venachain/client-sdk-go/types"
 	common_venachain "github.com/Venachain/client-sdk-go/venachain/common"
 )
 
@@ -40,6 +42,12 @@ type ICns interface {
 	CnsQueryByName(ctx context.Context) (string, error)
 	CnsQueryByAddress(ctx context.Context, address string) (string, error)
 	CnsQueryByAccount(ctx context.Context, account string) (string, error)
+	CnsQueryPage(ctx context.Context, pageNum, pageSize int) (string, error)
+	CnsEntries(ctx context.Context) ([]syscontracts.CnsEntry, error)
+	CnsEntriesPage(ctx context.Context, pageNum, pageSize int) ([]syscontracts.CnsEntry, error)
+	CnsEntriesByName(ctx context.Context) ([]syscontracts.CnsEntry, error)
+	CnsEntriesByAddress(ctx context.Context, address string) ([]syscontracts.CnsEntry, error)
+	CnsEntriesByAccount(ctx context.Context, account string) ([]syscontracts.CnsEntry, error)
 	CnsStateByAddress(ctx context.Context, address string) (int32, error)
 	CnsState(ctx context.Context) (int32, error)
 }
@@ -54,6 +62,8 @@ type IFireWall interface {
 	FwDelete(ctx context.Context, action, targetAddr, api string) (string, error)
 	FwReset(ctx context.Context, action, targetAddr, api string) (string, error)
 	FwClear(ctx context.Context, action string) (string, error)
+	GetFirewallStatus(ctx context.Context) (*FirewallStatus, error)
+	IsCallAllowed(ctx context.Context, contract, caller, api string) (FirewallDecision, error)
 }
 
 type INode interface {
@@ -62,6 +72,96 @@ type INode interface {
 	NodeUpdate(ctx context.Context, request syscontracts.NodeUpdateInfo) (string, error)
 	NodeQuery(ctx context.Context, request *syscontracts.NodeQueryInfo) (string, error)
 	NodeStat(ctx context.Context, request *syscontracts.NodeStatInfo) (int32, error)
+	Nodes(ctx context.Context) ([]Node, error)
+	NodesBy(ctx context.Context, request syscontracts.NodeQueryInfo) ([]Node, error)
+	Node(ctx context.Context) (*Node, error)
+	ConsensusNodes(ctx context.Context) ([]Node, error)
+	NormalEnodes(ctx context.Context) ([]Enode, error)
+	DeletedEnodes(ctx context.Context) ([]Enode, error)
+	NodeValidJoin(ctx context.Context, publicKey string) (bool, error)
+	PromoteToConsensus(ctx context.Context, delayNum uint64) (*packet.Receipt, error)
+	DemoteToObserver(ctx context.Context, delayNum uint64) (*packet.Receipt, error)
+	WaitConsensus(ctx context.Context, publicKey string, consensus bool) error
+	RegisterNode(ctx context.Context, reg *NodeRegistration) (*packet.Receipt, error)
+}
+
+type IGroup interface {
+	HasGroupOpPermission(ctx context.Context) (bool, error)
+	GroupCreate(ctx context.Context, info syscontracts.GroupInfo) (*GroupTxResult, error)
+	GroupAddBootNode(ctx context.Context, node syscontracts.BootNode) (*GroupTxResult, error)
+	GroupDelBootNode(ctx context.Context, node syscontracts.BootNode) (*GroupTxResult, error)
+	GroupUpdateBootNodes(ctx context.Context, nodes []syscontracts.BootNode) (*GroupTxResult, error)
+	GroupQuery(ctx context.Context) (*syscontracts.GroupInfo, error)
+	GroupQueryAll(ctx context.Context) ([]syscontracts.GroupInfo, error)
+}
+
+type IEvidence interface {
+	SaveEvidence(ctx context.Context, key string, digest string) (*EvidenceRecord, error)
+	SaveFile(ctx context.Context, key string, path string) (*EvidenceRecord, error)
+	SaveReader(ctx context.Context, key string, r io.Reader) (*EvidenceRecord, error)
+	SaveBatch(ctx context.Context, batchKey string, digests map[string]string) ([]EvidenceRecord, error)
+	GetEvidence(ctx context.Context, key string) (string, error)
+	GetBatch(ctx context.Context, batchKey string) (map[string]string, error)
+	Verify(ctx context.Context, key string, path string) (bool, error)
+	VerifyInBatch(ctx context.Context, batchKey string, key string, path string) (bool, error)
+}
+
+type INFT interface {
+	Mint(ctx context.Context, to string, token syscontracts.NFTToken) (*NFTTxResult, error)
+	BatchMint(ctx context.Context, to string, tokens []syscontracts.NFTToken) ([]NFTItemResult, *NFTTxResult, error)
+	Transfer(ctx context.Context, tokenID string, price uint64, to string, desc string) (*NFTTxResult, error)
+	BatchTransfer(ctx context.Context, transfers []NFTTransfer) ([]NFTItemResult, *NFTTxResult, error)
+	Mortgage(ctx context.Context, tokenID string, to string, desc string) (*NFTTxResult, error)
+	Redeem(ctx context.Context, tokenID string) (*NFTTxResult, error)
+	ChangeNFTData(ctx context.Context, tokenID string, update map[string]interface{}) (*NFTTxResult, error)
+	ModifyMinter(ctx context.Context, operation string, address string) (*NFTTxResult, error)
+	GetNFT(ctx context.Context, tokenID string) (*syscontracts.NFTToken, error)
+	Records(ctx context.Context, tokenID string) ([]syscontracts.NFTRecord, error)
+	NFTsByMinter(ctx context.Context, minter string) ([]syscontracts.NFTToken, error)
+	MortgagedNFTs(ctx context.Context, holder string) ([]syscontracts.NFTToken, error)
+	OwnerOf(ctx context.Context, tokenID string) (string, error)
+	BalanceOf(ctx context.Context, owner string) (uint64, error)
+	TotalSupply(ctx context.Context) (int32, error)
+	Tokens(pageSize int) *NFTIterator
+	TokensOf(owner string, pageSize int) *NFTIterator
+	Minters(pageSize int) *NFTIterator
+	TokenOwners(pageSize int) *NFTIterator
+}
+
+type IContractData interface {
+	Migrate(ctx context.Context, src string, dest string) (*packet.Receipt, error)
+	Upgrade(ctx context.Context, cnsClient *CnsClient, name string, version string) (*ContractUpgrade, error)
+	ContractExists(ctx context.Context, address string) (bool, error)
+}
+
+type IProxy interface {
+	XAExecute(ctx context.Context, crossID string, index int, total int, contract string, callData string) (*XAProof, error)
+	XARollback(ctx context.Context, crossID string, index int, contract string, rollbackCallData string) (*packet.Receipt, error)
+	XACommit(ctx context.Context, crossID string) (*packet.Receipt, error)
+	SaveProof(ctx context.Context, proof string) (*packet.Receipt, error)
+	GetProof(ctx context.Context, crossID string) (string, error)
+}
+
+type IProxyAdmin interface {
+	Config() ProxyConfig
+	Grant(ctx context.Context, address string) (*packet.Receipt, error)
+	Revoke(ctx context.Context, address string) (*packet.Receipt, error)
+	SetSignatureThreshold(ctx context.Context, threshold int) (*packet.Receipt, error)
+	NewProofCollector(proof *XAProof) *ProofCollector
+	SaveSignedProof(ctx context.Context, collector *ProofCollector) (*packet.Receipt, error)
+}
+
+type IRangeProof interface {
+	Prove(values []*big.Int, scope string) (string, error)
+	VerifyLocal(proof string, scope string) error
+	SubmitProof(ctx context.Context, userID string, proof string, pid string, scope string) (*RangeProofReceipt, error)
+	ProveAndSubmit(ctx context.Context, userID string, pid string, scope string, values []*big.Int) (*RangeProofReceipt, error)
+	GetResult(ctx context.Context, pid string) (*RangeProofResult, error)
+}
+
+type IConfidentialWallet interface {
+	Transfer(ctx context.Context, transfer *ConfidentialTransfer) (*packet.Receipt, error)
+	Burn(ctx context.Context, withdraw *ConfidentialWithdraw) (*packet.Receipt, error)
 }
 
 type IRole interface {
@@ -80,6 +180,19 @@ type IRole interface {
 	GetAddrListOfRole(ctx context.Context, role string) (string, error)
 	GetRoles(ctx context.Context, address string) (string, error)
 	HasRole(ctx context.Context, address, role string) (int32, error)
+	GrantRole(ctx context.Context, address string, role Role) (*packet.Receipt, error)
+	RevokeRole(ctx context.Context, address string, role Role) (*packet.Receipt, error)
+	GrantRoleByName(ctx context.Context, name string, role Role) (*packet.Receipt, error)
+	RevokeRoleByName(ctx context.Context, name string, role Role) (*packet.Receipt, error)
+	RolesOf(ctx context.Context, address string) ([]Role, error)
+	RolesByName(ctx context.Context, name string) ([]Role, error)
+	AddressesOf(ctx context.Context, role Role) ([]string, error)
+	CheckRole(ctx context.Context, address string, role Role) (bool, error)
+	GetAllUsers(ctx context.Context) ([]syscontracts.UserInfo, error)
+	Users() *UserIterator
+	RoleMatrix(ctx context.Context) (*RoleMatrix, error)
+	PlanProvision(ctx context.Context, manifest *RoleManifest) (*ProvisionPlan, error)
+	ApplyProvision(ctx context.Context, plan *ProvisionPlan) error
 }
 
 type ISysconfig interface {
@@ -93,4 +206,12 @@ type ISysconfig interface {
 	GetIsApproveDeployedContract(ctx context.Context) (uint32, error)
 	GetIsTxUseGas(ctx context.Context) (uint32, error)
 	GetVRFParams(ctx context.Context) (string, error)
+	GetIsBlockUseTrieHash(ctx context.Context) (uint32, error)
+	SetIsBlockUseTrieHash(ctx context.Context, isBlockUseTrieHash bool) (*SysConfigResult, error)
+	GetIntParam(ctx context.Context, key string) (uint64, error)
+	GetStrParam(ctx context.Context, key string) (string, error)
+	SetIntParam(ctx context.Context, key string, value uint64) (*SysConfigResult, error)
+	SetStrParam(ctx context.Context, key string, value string) (*SysConfigResult, error)
+	GetAll(ctx context.Context) (*SysConfig, error)
+	ApplySysConfig(ctx context.Context, desired *SysConfig) ([]SysConfigResult, error)
 }
@@ -0,0 +1,356 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/common"
+	"github.com/Venachain/client-sdk-go/packet"
+)
+
+// 跨链事务的阶段
+const (
+	XAPhaseExecuting   = "executing"
+	XAPhaseCommitting  = "committing"
+	XAPhaseCommitted   = "committed"
+	XAPhaseRollingBack = "rollingBack"
+	XAPhaseRolledBack  = "rolledBack"
+)
+
+var ErrXANotFound = errors.New("the xa transaction is not found")
+
+// 回滚不使用调用者的 ctx，调用者取消后仍然可以回滚已执行的调用
+var xaRollbackTimeout = 5 * time.Minute
+
+// XACall 跨链事务中的一个业务调用
+// Sent 为发送 execute 交易前保存的标记，发送后没有确认结果的调用按照已执行处理
+type XACall struct {
+	Index        int      `json:"index"`
+	ChannelID    string   `json:"channelID"`
+	Contract     string   `json:"contract"`
+	CallData     string   `json:"callData"`
+	RollbackData string   `json:"rollbackData"`
+	Sent         bool     `json:"sent"`
+	Executed     bool     `json:"executed"`
+	RolledBack   bool     `json:"rolledBack"`
+	Proof        *XAProof `json:"proof,omitempty"`
+}
+
+// XAState 协调者保存的跨链事务状态，用于崩溃后恢复
+type XAState struct {
+	XAID      string          `json:"xaID"`
+	Phase     string          `json:"phase"`
+	Calls     []*XACall       `json:"calls"`
+	Committed map[string]bool `json:"committed"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Proofs 已执行的业务调用的凭证
+func (state *XAState) Proofs() []*XAProof {
+	var proofs []*XAProof
+	for _, call := range state.Calls {
+		if call.Proof != nil {
+			proofs = append(proofs, call.Proof)
+		}
+	}
+	return proofs
+}
+
+// 按照业务调用的顺序返回涉及的链
+func (state *XAState) channels() []string {
+	var channels []string
+	seen := make(map[string]bool)
+	for _, call := range state.Calls {
+		if !seen[call.ChannelID] {
+			seen[call.ChannelID] = true
+			channels = append(channels, call.ChannelID)
+		}
+	}
+	return channels
+}
+
+// XAStore 跨链事务状态的持久化接口，Load 找不到状态时返回 ErrXANotFound
+type XAStore interface {
+	Save(state *XAState) error
+	Load(xaID string) (*XAState, error)
+}
+
+// FileXAStore 将跨链事务状态以 json 文件的形式保存在 Dir 目录下
+type FileXAStore struct {
+	Dir string
+	mu  sync.Mutex
+}
+
+func NewFileXAStore(dir string) (*FileXAStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileXAStore{Dir: dir}, nil
+}
+
+func (store *FileXAStore) path(xaID string) string {
+	return filepath.Join(store.Dir, url.PathEscape(xaID)+".json")
+}
+
+// Save 先写入临时文件再重命名，避免崩溃时留下不完整的状态
+func (store *FileXAStore) Save(state *XAState) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := store.path(state.XAID)
+	if err := os.WriteFile(path+".tmp", bytes, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (store *FileXAStore) Load(xaID string) (*XAState, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	bytes, err := os.ReadFile(store.path(xaID))
+	if os.IsNotExist(err) {
+		return nil, ErrXANotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := new(XAState)
+	if err := json.Unmarshal(bytes, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// XACallEncoder 将业务合约调用编码为代理合约 execute 和 rollback 中的 callData
+type XACallEncoder func(req *common.Request) (string, error)
+
+// NewAbiCallEncoder 按照业务合约的 abi 编码调用，abis 为业务合约地址或 cns 名称到 abi 文件路径的映射
+func NewAbiCallEncoder(abis map[string]string, vmType string) XACallEncoder {
+	contents := make(map[string]string, len(abis))
+	for contract, abiPath := range abis {
+		contents[strings.ToLower(contract)] = abiPath
+	}
+	return func(req *common.Request) (string, error) {
+		abiPath, ok := contents[strings.ToLower(req.Contract)]
+		if !ok {
+			return "", fmt.Errorf("the abi of contract %s is not found", req.Contract)
+		}
+		content, err := GetContractByAbiPath(abiPath)
+		if err != nil {
+			return "", err
+		}
+		cns, _, err := packet.CnsParse(req.Contract)
+		if err != nil {
+			return "", err
+		}
+		methodAbi, err := content.GetFuncFromAbi(req.Method)
+		if err != nil {
+			return "", err
+		}
+		funcArgs, err := methodAbi.StringToArgs(req.Params)
+		if err != nil {
+			return "", err
+		}
+		dataGenerator := packet.NewContractDataGen(packet.NewData(funcArgs, methodAbi), content, cns.TxType)
+		dataGenerator.SetInterpreter(vmType, cns.Name, cns.TxType)
+		return dataGenerator.CombineData()
+	}
+}
+
+// XACoordinator 跨链事务的两阶段提交协调者
+// 依次在各条链的代理合约上执行业务调用，全部成功后在每条链上提交，任一调用失败时回滚已执行的调用
+type XACoordinator struct {
+	// 链的 channelID 到该链代理合约客户端的映射
+	Chains  map[string]*ProxyClient
+	Encoder XACallEncoder
+	// 为空时不保存状态，无法恢复
+	Store XAStore
+}
+
+func NewXACoordinator(chains map[string]*ProxyClient, encoder XACallEncoder, store XAStore) *XACoordinator {
+	return &XACoordinator{Chains: chains, Encoder: encoder, Store: store}
+}
+
+// Run 执行跨链事务，rollbacks 为每个业务调用对应的回滚调用
+// 执行失败并回滚成功时返回的错误包含执行失败的原因，状态为 rolledBack
+func (coordinator *XACoordinator) Run(ctx context.Context, req *common.XARequest, rollbacks []*common.Request) (*XAState, error) {
+	if req.XAID == "" || len(req.Requests) == 0 {
+		return nil, errors.New("the xa id and requests are required")
+	}
+	if len(rollbacks) != len(req.Requests) {
+		return nil, fmt.Errorf("got %d rollback requests for %d requests", len(rollbacks), len(req.Requests))
+	}
+	if coordinator.Store != nil {
+		if _, err := coordinator.Store.Load(req.XAID); err != ErrXANotFound {
+			if err == nil {
+				err = fmt.Errorf("the xa transaction %s already exists", req.XAID)
+			}
+			return nil, err
+		}
+	}
+
+	state := &XAState{XAID: req.XAID, Phase: XAPhaseExecuting, Committed: make(map[string]bool)}
+	for i, request := range req.Requests {
+		if _, ok := coordinator.Chains[request.ChannelID]; !ok {
+			return nil, fmt.Errorf("the channel %s is not configured", request.ChannelID)
+		}
+		if rollbacks[i] == nil {
+			return nil, fmt.Errorf("the rollback request %d is empty", i)
+		}
+		if rollbacks[i].Contract != "" && !strings.EqualFold(rollbacks[i].Contract, request.Contract) {
+			return nil, fmt.Errorf("the rollback request %d calls %s, expect %s", i, rollbacks[i].Contract, request.Contract)
+		}
+		callData, err := coordinator.Encoder(request)
+		if err != nil {
+			return nil, fmt.Errorf("encode request %d error: %v", i, err)
+		}
+		rollback := *rollbacks[i]
+		rollback.Contract = request.Contract
+		rollbackData, err := coordinator.Encoder(&rollback)
+		if err != nil {
+			return nil, fmt.Errorf("encode rollback request %d error: %v", i, err)
+		}
+		state.Calls = append(state.Calls, &XACall{
+			Index:        i,
+			ChannelID:    request.ChannelID,
+			Contract:     request.Contract,
+			CallData:     callData,
+			RollbackData: rollbackData,
+		})
+	}
+	if err := coordinator.save(state); err != nil {
+		return nil, err
+	}
+
+	total := len(state.Calls)
+	for _, call := range state.Calls {
+		call.Sent = true
+		if err := coordinator.save(state); err != nil {
+			return state, err
+		}
+		proof, err := coordinator.Chains[call.ChannelID].XAExecute(ctx, state.XAID, call.Index, total, call.Contract, call.CallData)
+		if err != nil {
+			// 代理合约返回失败时调用没有执行，其他错误时交易可能已经上链
+			var eventErr *SysEventError
+			if errors.As(err, &eventErr) {
+				call.Sent = false
+			}
+			state.Error = fmt.Sprintf("channel %s: %v", call.ChannelID, err)
+			if rbErr := coordinator.rollbackDetached(state); rbErr != nil {
+				return state, fmt.Errorf("%s, and the rollback failed: %v", state.Error, rbErr)
+			}
+			return state, fmt.Errorf("the xa transaction %s is rolled back: %s", state.XAID, state.Error)
+		}
+		proof.ChannelID = call.ChannelID
+		call.Executed, call.Proof = true, proof
+		if err := coordinator.save(state); err != nil {
+			if rbErr := coordinator.rollbackDetached(state); rbErr != nil {
+				return state, fmt.Errorf("%v, and the rollback failed: %v", err, rbErr)
+			}
+			return state, err
+		}
+	}
+	return state, coordinator.commit(ctx, state)
+}
+
+// Recover 从保存的状态中恢复跨链事务
+// 执行阶段中断的事务回滚已执行和已发送的调用，提交和回滚阶段中断的事务继续提交或回滚
+func (coordinator *XACoordinator) Recover(ctx context.Context, xaID string) (*XAState, error) {
+	if coordinator.Store == nil {
+		return nil, errors.New("the xa store is not configured")
+	}
+	state, err := coordinator.Store.Load(xaID)
+	if err != nil {
+		return nil, err
+	}
+	for _, call := range state.Calls {
+		if _, ok := coordinator.Chains[call.ChannelID]; !ok {
+			return state, fmt.Errorf("the channel %s is not configured", call.ChannelID)
+		}
+	}
+	if state.Committed == nil {
+		state.Committed = make(map[string]bool)
+	}
+	switch state.Phase {
+	case XAPhaseExecuting, XAPhaseRollingBack:
+		return state, coordinator.rollback(ctx, state)
+	case XAPhaseCommitting:
+		return state, coordinator.commit(ctx, state)
+	case XAPhaseCommitted, XAPhaseRolledBack:
+		return state, nil
+	}
+	return state, fmt.Errorf("unknown xa phase %s", state.Phase)
+}
+
+// 所有调用执行成功后在每条链上提交，提交失败时停留在 committing 阶段，可以通过 Recover 重试
+func (coordinator *XACoordinator) commit(ctx context.Context, state *XAState) error {
+	state.Phase = XAPhaseCommitting
+	if err := coordinator.save(state); err != nil {
+		return err
+	}
+	for _, channel := range state.channels() {
+		if state.Committed[channel] {
+			continue
+		}
+		if _, err := coordinator.Chains[channel].XACommit(ctx, state.XAID); err != nil {
+			return fmt.Errorf("channel %s: %v", channel, err)
+		}
+		state.Committed[channel] = true
+		if err := coordinator.save(state); err != nil {
+			return err
+		}
+	}
+	state.Phase = XAPhaseCommitted
+	return coordinator.save(state)
+}
+
+// rollbackDetached 在执行失败后回滚，使用独立的 ctx，调用者的 ctx 已经取消时仍然可以回滚
+func (coordinator *XACoordinator) rollbackDetached(state *XAState) error {
+	ctx, cancel := context.WithTimeout(context.Background(), xaRollbackTimeout)
+	defer cancel()
+	return coordinator.rollback(ctx, state)
+}
+
+// 按照执行的逆序回滚已执行和已发送但没有确认结果的调用，回滚失败时停留在 rollingBack 阶段，可以通过 Recover 重试
+func (coordinator *XACoordinator) rollback(ctx context.Context, state *XAState) error {
+	state.Phase = XAPhaseRollingBack
+	if err := coordinator.save(state); err != nil {
+		return err
+	}
+	for i := len(state.Calls) - 1; i >= 0; i-- {
+		call := state.Calls[i]
+		if !(call.Executed || call.Sent) || call.RolledBack {
+			continue
+		}
+		if _, err := coordinator.Chains[call.ChannelID].XARollback(ctx, state.XAID, call.Index, call.Contract, call.RollbackData); err != nil {
+			return fmt.Errorf("channel %s: %v", call.ChannelID, err)
+		}
+		call.RolledBack = true
+		if err := coordinator.save(state); err != nil {
+			return err
+		}
+	}
+	state.Phase = XAPhaseRolledBack
+	return coordinator.save(state)
+}
+
+func (coordinator *XACoordinator) save(state *XAState) error {
+	if coordinator.Store == nil {
+		return nil
+	}
+	if err := coordinator.Store.Save(state); err != nil {
+		return fmt.Errorf("save the xa transaction %s error: %v", state.XAID, err)
+	}
+	return nil
+}
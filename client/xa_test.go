@@ -0,0 +1,223 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/common"
+	"github.com/Venachain/client-sdk-go/packet"
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBusinessContract = "0x2000000000000000000000000000000000000001"
+
+// 模拟一条链上的代理合约，fail 中的业务调用执行失败，garbled 中的业务调用执行成功但回执中的事件无法解析
+type mockProxy struct {
+	mockContract
+	fail      map[string]bool
+	garbled   map[string]bool
+	onExecute func(key string)
+	executed  []string
+	rolled    []string
+	committed []string
+	others    []string
+}
+
+func (m *mockProxy) handlers(t *testing.T) map[string]mockHandler {
+	return m.serve(t, nil, func(call *packet.DecodedCall) []*packet.Log {
+		code, msg := uint64(0), "success"
+		switch call.Method {
+		case "execute":
+			key := fmt.Sprintf("%v:%v", call.Params[0].Value, call.Params[1].Value)
+			if m.fail[key] {
+				code, msg = 1, "business contract failed"
+			} else {
+				m.executed = append(m.executed, key)
+				msg = "proof of " + key
+			}
+			if m.onExecute != nil {
+				m.onExecute(key)
+			}
+			if m.garbled[key] {
+				return []*packet.Log{testSysLog(precompile.ContractProxyAddress, call.Method, "garbled")}
+			}
+		case "rollback":
+			m.rolled = append(m.rolled, fmt.Sprintf("%v:%v", call.Params[0].Value, call.Params[1].Value))
+		case "commit":
+			m.committed = append(m.committed, call.Params[0].Value.(string))
+		default:
+			m.others = append(m.others, fmt.Sprintf("%s:%v", call.Method, call.Params[0].Value))
+		}
+		return []*packet.Log{testSysLog(precompile.ContractProxyAddress, call.Method, code, msg)}
+	})
+}
+
+func newTestXACoordinator(t *testing.T, store XAStore) (*XACoordinator, map[string]*mockProxy) {
+	abiPath := filepath.Join(t.TempDir(), "business.abi.json")
+	abi := `[{"name":"set","inputs":[{"name":"value","type":"string"}],"outputs":[],"constant":"false","type":"function"}]`
+	assert.NoError(t, os.WriteFile(abiPath, []byte(abi), 0600))
+
+	key := newTestKey(t)
+	mocks := make(map[string]*mockProxy)
+	chains := make(map[string]*ProxyClient)
+	for _, channel := range []string{"001", "002"} {
+		m := &mockProxy{fail: make(map[string]bool), garbled: make(map[string]bool)}
+		server, url := newMockNode(t, m.handlers(t))
+		t.Cleanup(server.Close)
+		proxyClient, err := NewProxyClientWithKey(context.Background(), url, key)
+		assert.NoError(t, err)
+		t.Cleanup(proxyClient.RpcClient.Close)
+		mocks[channel], chains[channel] = m, proxyClient
+	}
+	encoder := NewAbiCallEncoder(map[string]string{testBusinessContract: abiPath}, "wasm")
+	return NewXACoordinator(chains, encoder, store), mocks
+}
+
+func testXARequest(xaID string) (*common.XARequest, []*common.Request) {
+	req := &common.XARequest{XAID: xaID}
+	var rollbacks []*common.Request
+	for i, channel := range []string{"001", "002", "001"} {
+		req.Requests = append(req.Requests, &common.Request{ChannelID: channel, Contract: testBusinessContract, Method: "set", Params: []string{fmt.Sprint(i)}})
+		rollbacks = append(rollbacks, &common.Request{Method: "set", Params: []string{""}})
+	}
+	return req, rollbacks
+}
+
+func TestXACoordinator_Run(t *testing.T) {
+	store, err := NewFileXAStore(t.TempDir())
+	assert.NoError(t, err)
+	coordinator, mocks := newTestXACoordinator(t, store)
+	ctx := context.Background()
+
+	req, rollbacks := testXARequest("xa01")
+	state, err := coordinator.Run(ctx, req, rollbacks)
+	assert.NoError(t, err)
+	assert.Equal(t, XAPhaseCommitted, state.Phase)
+	assert.Len(t, state.Proofs(), 3)
+	assert.Equal(t, "proof of xa01:1", state.Proofs()[1].Msg)
+	assert.Equal(t, "002", state.Proofs()[1].ChannelID)
+	assert.Equal(t, []string{"xa01:0", "xa01:2"}, mocks["001"].executed)
+	assert.Equal(t, []string{"xa01"}, mocks["001"].committed)
+	assert.Equal(t, []string{"xa01"}, mocks["002"].committed)
+
+	_, err = coordinator.Run(ctx, req, rollbacks)
+	assert.Error(t, err)
+
+	// 缺少回滚调用时不执行
+	req, rollbacks = testXARequest("xa03")
+	rollbacks[1] = nil
+	_, err = coordinator.Run(ctx, req, rollbacks)
+	assert.EqualError(t, err, "the rollback request 1 is empty")
+
+	// 第三个调用失败时按逆序回滚前两个调用
+	mocks["001"].fail["xa02:2"] = true
+	req, rollbacks = testXARequest("xa02")
+	state, err = coordinator.Run(ctx, req, rollbacks)
+	assert.Error(t, err)
+	assert.Equal(t, XAPhaseRolledBack, state.Phase)
+	assert.Equal(t, []string{"xa02:0"}, mocks["001"].rolled)
+	assert.Equal(t, []string{"xa02:1"}, mocks["002"].rolled)
+	assert.Len(t, mocks["001"].committed, 1)
+
+	saved, err := store.Load("xa02")
+	assert.NoError(t, err)
+	assert.Equal(t, state, saved)
+}
+
+// 第 failAt 次保存时返回错误
+type failingXAStore struct {
+	XAStore
+	saves, failAt int
+}
+
+func (store *failingXAStore) Save(state *XAState) error {
+	if store.saves++; store.saves == store.failAt {
+		return errors.New("disk full")
+	}
+	return store.XAStore.Save(state)
+}
+
+func TestXACoordinator_RunUnconfirmed(t *testing.T) {
+	fileStore, err := NewFileXAStore(t.TempDir())
+	assert.NoError(t, err)
+	store := &failingXAStore{XAStore: fileStore}
+	coordinator, mocks := newTestXACoordinator(t, store)
+
+	// 交易已上链但结果无法确认时，回滚该调用
+	mocks["002"].garbled["xa05:1"] = true
+	req, rollbacks := testXARequest("xa05")
+	state, err := coordinator.Run(context.Background(), req, rollbacks)
+	assert.Error(t, err)
+	assert.Equal(t, XAPhaseRolledBack, state.Phase)
+	assert.Equal(t, []string{"xa05:1"}, mocks["002"].rolled)
+	assert.Equal(t, []string{"xa05:0"}, mocks["001"].rolled)
+
+	// 调用者的 ctx 在执行后取消，仍然回滚已执行的调用
+	ctx, cancel := context.WithCancel(context.Background())
+	mocks["002"].onExecute = func(key string) {
+		if key == "xa06:1" {
+			cancel()
+		}
+	}
+	req, rollbacks = testXARequest("xa06")
+	state, err = coordinator.Run(ctx, req, rollbacks)
+	assert.Error(t, err)
+	assert.Equal(t, XAPhaseRolledBack, state.Phase)
+	assert.Contains(t, mocks["001"].rolled, "xa06:0")
+	assert.Contains(t, mocks["002"].rolled, "xa06:1")
+
+	// 执行成功后保存状态失败时，回滚该调用
+	store.saves, store.failAt = 0, 3
+	req, rollbacks = testXARequest("xa07")
+	state, err = coordinator.Run(context.Background(), req, rollbacks)
+	assert.Error(t, err)
+	assert.Equal(t, XAPhaseRolledBack, state.Phase)
+	assert.Contains(t, mocks["001"].rolled, "xa07:0")
+}
+
+func TestXACoordinator_Recover(t *testing.T) {
+	store, err := NewFileXAStore(t.TempDir())
+	assert.NoError(t, err)
+	coordinator, mocks := newTestXACoordinator(t, store)
+	ctx := context.Background()
+
+	_, err = coordinator.Recover(ctx, "missing")
+	assert.Equal(t, ErrXANotFound, err)
+
+	// 提交阶段中断，只在还没有提交的链上提交
+	assert.NoError(t, store.Save(&XAState{
+		XAID:  "xa03",
+		Phase: XAPhaseCommitting,
+		Calls: []*XACall{
+			{Index: 0, ChannelID: "001", Contract: testBusinessContract, Executed: true},
+			{Index: 1, ChannelID: "002", Contract: testBusinessContract, Executed: true},
+		},
+		Committed: map[string]bool{"001": true},
+	}))
+	state, err := coordinator.Recover(ctx, "xa03")
+	assert.NoError(t, err)
+	assert.Equal(t, XAPhaseCommitted, state.Phase)
+	assert.Empty(t, mocks["001"].committed)
+	assert.Equal(t, []string{"xa03"}, mocks["002"].committed)
+
+	// 执行阶段中断，回滚已执行和已发送的调用
+	assert.NoError(t, store.Save(&XAState{
+		XAID:  "xa04",
+		Phase: XAPhaseExecuting,
+		Calls: []*XACall{
+			{Index: 0, ChannelID: "002", Contract: testBusinessContract, Sent: true, Executed: true},
+			{Index: 1, ChannelID: "001", Contract: testBusinessContract, Sent: true},
+			{Index: 2, ChannelID: "001", Contract: testBusinessContract},
+		},
+	}))
+	state, err = coordinator.Recover(ctx, "xa04")
+	assert.NoError(t, err)
+	assert.Equal(t, XAPhaseRolledBack, state.Phase)
+	assert.Equal(t, []string{"xa04:0"}, mocks["002"].rolled)
+	assert.Equal(t, []string{"xa04:1"}, mocks["001"].rolled)
+}
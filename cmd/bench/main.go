@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Venachain/client-sdk-go/bench"
+	"github.com/Venachain/client-sdk-go/client"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+)
+
+// 合约压测工具，例如：
+//
+//	bench -endpoints 127.0.0.1:6791 -keyfiles key1.json,key2.json -pass 0 \
+//	  -contract 0x0000000000000000000000000000000000000099 -func saveEvidence -params "key_{seq},value" \
+//	  -tps 100 -total 1000 -json report.json -csv report.csv
+//
+// 使用 -mock 启动本地模拟节点自测
+var (
+	endpoints   = flag.String("endpoints", "127.0.0.1:6791", "comma separated node endpoints, ip:port")
+	keyfiles    = flag.String("keyfiles", "", "comma separated keyfile paths")
+	passphrase  = flag.String("pass", "", "passphrase of the keyfiles")
+	seed        = flag.String("seed", "", "derive the keys from the seed instead of the keyfiles")
+	accounts    = flag.Int("accounts", 4, "number of the derived keys")
+	contract    = flag.String("contract", "", "contract address or cns name")
+	abiPath     = flag.String("abi", "", "abi file path, can be empty for the precompiled contracts")
+	vmType      = flag.String("vm", "wasm", "contract vm type, wasm or evm")
+	funcName    = flag.String("func", "", "contract function")
+	params      = flag.String("params", "", "comma separated parameter template, {seq} and {rand} are replaced")
+	tps         = flag.Int("tps", 0, "transactions per second, 0 means unlimited")
+	concurrency = flag.Int("concurrency", 1, "number of the sending goroutines")
+	total       = flag.Int("total", 0, "total number of the transactions")
+	duration    = flag.Duration("duration", 0, "benchmark duration")
+	strategy    = flag.String("strategy", "roundrobin", "account strategy, roundrobin or leastpending")
+	jsonPath    = flag.String("json", "", "write the json report to the file, - for stdout")
+	csvPath     = flag.String("csv", "", "write the csv report to the file, - for stdout")
+	samplesPath = flag.String("samples", "", "write the per transaction csv to the file")
+	mock        = flag.Int("mock", 0, "start the given number of local mock nodes instead of using the endpoints")
+)
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg := bench.Config{
+		Contract:    *contract,
+		AbiPath:     *abiPath,
+		VmType:      *vmType,
+		Func:        *funcName,
+		TPS:         *tps,
+		Concurrency: *concurrency,
+		Total:       *total,
+		Duration:    *duration,
+	}
+	if *params != "" {
+		cfg.Params = bench.TemplateParams(strings.Split(*params, ",")...)
+	}
+	if *strategy == "leastpending" {
+		cfg.Strategy = client.LeastPending
+	}
+
+	var err error
+	if *mock > 0 {
+		for i := 0; i < *mock; i++ {
+			node, err := bench.NewMockNode(time.Second)
+			if err != nil {
+				return err
+			}
+			defer node.Close()
+			cfg.Endpoints = append(cfg.Endpoints, node.URL)
+		}
+	} else if cfg.Endpoints, err = parseEndpoints(*endpoints); err != nil {
+		return err
+	}
+	if cfg.Keys, err = loadKeys(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		<-sig
+		cancel()
+	}()
+
+	report, err := bench.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if *jsonPath == "" && *csvPath == "" {
+		*jsonPath = "-"
+	}
+	if err = writeTo(*jsonPath, report.WriteJSON); err != nil {
+		return err
+	}
+	if err = writeTo(*csvPath, report.WriteCSV); err != nil {
+		return err
+	}
+	return writeTo(*samplesPath, report.WriteSamplesCSV)
+}
+
+func parseEndpoints(s string) ([]client.URL, error) {
+	var urls []client.URL
+	for _, endpoint := range strings.Split(s, ",") {
+		host, port, err := net.SplitHostPort(strings.TrimSpace(endpoint))
+		if err != nil {
+			return nil, err
+		}
+		p, err := strconv.ParseUint(port, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, client.NewURL(host, p))
+	}
+	return urls, nil
+}
+
+func loadKeys() ([]*keystore.Key, error) {
+	if *seed != "" {
+		return client.DeriveKeys([]byte(*seed), *accounts)
+	}
+	if *keyfiles == "" {
+		return nil, errors.New("either keyfiles or seed must be provided")
+	}
+	var keys []*keystore.Key
+	for _, path := range strings.Split(*keyfiles, ",") {
+		key, err := client.NewKey(strings.TrimSpace(path), *passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("load keyfile %s error: %v", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func writeTo(path string, write func(io.Writer) error) error {
+	switch path {
+	case "":
+		return nil
+	case "-":
+		if err := write(os.Stdout); err != nil {
+			return err
+		}
+		fmt.Println()
+		return nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return write(file)
+}
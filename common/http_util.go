@@ -32,6 +32,7 @@ type TxParams struct {
 	GasPrice string                    `json:"gasPrice"`
 	Value    string                    `json:"value"`
 	Data     string                    `json:"data"`
+	Nonce    string                    `json:"nonce,omitempty"` // 为空时签名使用随机 nonce
 }
 
 func Send(params interface{}, action string, url string) (string, error) {
@@ -112,11 +113,37 @@ func (tx *TxParams) SendMode(key *keystore.Key) ([]interface{}, string, error) {
 
 // GetSignedTx gets the signed transaction
 func (tx *TxParams) GetSignedTx(key *keystore.Key) (string, error) {
+	txSign, err := tx.SignTx(key)
+	if err != nil {
+		return "", err
+	}
+
+	str, err := rlpEncodeSignedTx(txSign)
+	if err != nil {
+		return "", err
+	}
+
+	return str, nil
+}
+
+// SignTx converts the TxParams object to types.Transaction object and signs it,
+// a random nonce is used if the Nonce of TxParams is empty
+func (tx *TxParams) SignTx(key *keystore.Key) (*types.Transaction, error) {
 
 	var txSign *types.Transaction
 
+	if key == nil || key.PrivateKey == nil {
+		return nil, errors.New("the private key of the transaction is empty")
+	}
+
 	// convert the TxParams object to types.Transaction object
 	nonce := getNonceRand()
+	if tx.Nonce != "" {
+		var err error
+		if nonce, err = hexutil.DecodeUint64(tx.Nonce); err != nil {
+			return nil, fmt.Errorf("invalid nonce %s: %v", tx.Nonce, err)
+		}
+	}
 	value, _ := hexutil.DecodeBig(tx.Value)
 	gas, _ := hexutil.DecodeUint64(tx.Gas)
 	gasPrice, _ := hexutil.DecodeBig(tx.GasPrice)
@@ -129,16 +156,13 @@ func (tx *TxParams) GetSignedTx(key *keystore.Key) (string, error) {
 	}
 
 	// todo: choose the correct signer
-	txSign, _ = types.SignTx(txSign, types.HomesteadSigner{}, key.PrivateKey)
 	/// txSign, _ = types.SignTx(txSign, types.NewEIP155Signer(big.NewInt(300)), priv)
-	/// utl.Logger.Printf("the signed transaction is %v\n", txSign)
-
-	str, err := rlpEncodeSignedTx(txSign)
-	if err != nil {
-		return "", err
-	}
+	return types.SignTx(txSign, types.HomesteadSigner{}, key.PrivateKey)
+}
 
-	return str, nil
+// NewNonce generates a nonce the same way as the signed transactions do
+func NewNonce() uint64 {
+	return getNonceRand()
 }
 
 // RlpEncode encode the input value by RLP and convert the output bytes to hex string
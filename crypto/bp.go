@@ -3,17 +3,29 @@ package crypto
 import (
 	"math/big"
 
-	"github.com/Venachain/client-sdk-go/venachain/crypto"
 	"github.com/Venachain/client-sdk-go/venachain/crypto/bp"
 )
 
 // 输入两个值和范围，生成proof
 func GetProof(value []*big.Int, scope string) (string, error) {
-	range_hash := crypto.Keccak256([]byte(scope))
-	param := bp.GenerateAggBpStatement_range(2, 16, range_hash)
-	proof, err := bp.AggBpProve_s(param, value)
+	return GetRangeProof(value, scope, 2, 16)
+}
+
+// 按照范围生成聚合 m 个 n 位数值的证明参数，相同的参数只生成一次
+func RangeStatement(scope string, m, n int64) *bp.AggBpStatement {
+	return cachedStatement(scope, m, n)
+}
+
+// 输入 m 个值和范围，生成 n 位的聚合范围证明
+func GetRangeProof(value []*big.Int, scope string, m, n int64) (string, error) {
+	proof, err := bp.AggBpProve_s(RangeStatement(scope, m, n), value)
 	if err != nil {
 		return "", err
 	}
 	return proof, nil
 }
+
+// 验证聚合范围证明
+func VerifyRangeProof(proof string, scope string, m, n int64) (bool, error) {
+	return bp.AggBpVerify_s(proof, RangeStatement(scope, m, n))
+}
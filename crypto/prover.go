@@ -0,0 +1,214 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/crypto/bp"
+)
+
+var ErrProverClosed = errors.New("the prover is closed")
+
+// 生成证明参数需要对每个生成元做哈希映射，按照 (m, n, 范围哈希) 缓存
+type statementKey struct {
+	m, n      int64
+	rangeHash string
+}
+
+type cachedStatementEntry struct {
+	statement *bp.AggBpStatement
+	lastUsed  uint64
+}
+
+// 范围由调用者传入，缓存的证明参数数量有上限，超出时淘汰最久没有使用的参数
+var maxCachedStatements = 64
+
+var (
+	statementsMu  sync.Mutex
+	statements    = make(map[statementKey]*cachedStatementEntry)
+	statementsUse uint64
+)
+
+func cachedStatement(scope string, m, n int64) *bp.AggBpStatement {
+	key := statementKey{m, n, string(crypto.Keccak256([]byte(scope)))}
+	statementsMu.Lock()
+	if entry, ok := statements[key]; ok {
+		statementsUse++
+		entry.lastUsed = statementsUse
+		statementsMu.Unlock()
+		return entry.statement
+	}
+	statementsMu.Unlock()
+
+	// 生成参数比较耗时，不持有锁，同时生成相同参数时使用先放入缓存的结果
+	statement := bp.GenerateAggBpStatement_range(m, n, []byte(key.rangeHash))
+
+	statementsMu.Lock()
+	defer statementsMu.Unlock()
+	statementsUse++
+	if entry, ok := statements[key]; ok {
+		entry.lastUsed = statementsUse
+		return entry.statement
+	}
+	for len(statements) >= maxCachedStatements {
+		var oldest statementKey
+		var oldestUse uint64
+		first := true
+		for k, entry := range statements {
+			if first || entry.lastUsed < oldestUse {
+				oldest, oldestUse, first = k, entry.lastUsed, false
+			}
+		}
+		delete(statements, oldest)
+	}
+	statements[key] = &cachedStatementEntry{statement: statement, lastUsed: statementsUse}
+	return statement
+}
+
+type proveJob struct {
+	ctx    context.Context
+	values []*big.Int
+	scope  string
+	m, n   int64
+	result chan proveResult
+}
+
+type proveResult struct {
+	proof string
+	err   error
+}
+
+// Prover 并行生成范围证明，任务放入有界队列中由固定数量的 worker 处理
+type Prover struct {
+	jobs      chan *proveJob
+	quit      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewProver workers 为并行生成证明的数量，为 0 时使用 cpu 个数，queueSize 为等待处理的任务数量上限
+func NewProver(workers int, queueSize int) *Prover {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	prover := &Prover{
+		jobs: make(chan *proveJob, queueSize),
+		quit: make(chan struct{}),
+	}
+	prover.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go prover.work()
+	}
+	return prover
+}
+
+func (prover *Prover) work() {
+	defer prover.wg.Done()
+	for {
+		select {
+		case <-prover.quit:
+			return
+		case job := <-prover.jobs:
+			// 等待期间已经取消的任务不再生成证明
+			if err := job.ctx.Err(); err != nil {
+				job.result <- proveResult{err: err}
+				continue
+			}
+			proof, err := bp.AggBpProve_s(cachedStatement(job.scope, job.m, job.n), job.values)
+			job.result <- proveResult{proof, err}
+		}
+	}
+}
+
+// Statement 范围 scope 下聚合 m 个 n 位数值的证明参数
+func (prover *Prover) Statement(scope string, m, n int64) *bp.AggBpStatement {
+	return cachedStatement(scope, m, n)
+}
+
+// Prove 生成聚合范围证明，队列已满时等待，直到 ctx 取消
+// values 的个数必须为 m，每个值都在 [0, 2^n) 中
+func (prover *Prover) Prove(ctx context.Context, values []*big.Int, scope string, m, n int64) (string, error) {
+	if int64(len(values)) != m {
+		return "", fmt.Errorf("got %d values, expect %d", len(values), m)
+	}
+	for _, v := range values {
+		if v == nil || v.Sign() < 0 || int64(v.BitLen()) > n {
+			return "", fmt.Errorf("the value %v is out of the %d bits range", v, n)
+		}
+	}
+	job := &proveJob{ctx: ctx, values: values, scope: scope, m: m, n: n, result: make(chan proveResult, 1)}
+	select {
+	case <-prover.quit:
+		return "", ErrProverClosed
+	default:
+	}
+	select {
+	case prover.jobs <- job:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-prover.quit:
+		return "", ErrProverClosed
+	}
+	select {
+	case result := <-job.result:
+		return result.proof, result.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-prover.quit:
+		return "", ErrProverClosed
+	}
+}
+
+// ProveBatch 并行生成多组数值的证明，结果与 values 的顺序一致，任一证明失败时取消其余的任务
+func (prover *Prover) ProveBatch(ctx context.Context, values [][]*big.Int, scope string, m, n int64) ([]string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	proofs := make([]string, len(values))
+	errs := make(chan error, len(values))
+	var wg sync.WaitGroup
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			proof, err := prover.Prove(ctx, values[i], scope, m, n)
+			if err != nil {
+				errs <- err
+				cancel()
+				return
+			}
+			proofs[i] = proof
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return proofs, nil
+}
+
+// Verify 验证一个聚合范围证明
+func (prover *Prover) Verify(proof string, scope string, m, n int64) (bool, error) {
+	return bp.AggBpVerify_s(proof, cachedStatement(scope, m, n))
+}
+
+// BatchVerify 使用多标量乘法一次验证同一范围下的多个证明，返回 false 时至少有一个证明无效
+func (prover *Prover) BatchVerify(proofs []string, scope string, m, n int64) (bool, error) {
+	return bp.AggBpBatchVerify_s(proofs, cachedStatement(scope, m, n))
+}
+
+// Close 停止所有 worker，等待中的任务返回 ErrProverClosed
+func (prover *Prover) Close() {
+	prover.closeOnce.Do(func() {
+		close(prover.quit)
+	})
+	prover.wg.Wait()
+}
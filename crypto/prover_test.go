@@ -0,0 +1,121 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func testValues(k int) [][]*big.Int {
+	values := make([][]*big.Int, k)
+	for i := range values {
+		values[i] = []*big.Int{big.NewInt(int64(i)), big.NewInt(int64(100 + i))}
+	}
+	return values
+}
+
+func TestProver_ProveBatch(t *testing.T) {
+	prover := NewProver(2, 1)
+	defer prover.Close()
+	scope := "0_255"
+	if prover.Statement(scope, 2, 8) != RangeStatement(scope, 2, 8) {
+		t.Fatal("the statement is not cached")
+	}
+
+	proofs, err := prover.ProveBatch(context.Background(), testValues(4), scope, 2, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, proof := range proofs {
+		if ok, err := prover.Verify(proof, scope, 2, 8); !ok || err != nil {
+			t.Fatalf("verify proof failed: %v", err)
+		}
+	}
+	if ok, err := prover.BatchVerify(proofs, scope, 2, 8); !ok || err != nil {
+		t.Fatalf("batch verify failed: %v", err)
+	}
+
+	// 混入其他范围的证明后批量验证失败
+	other, err := GetRangeProof([]*big.Int{big.NewInt(1), big.NewInt(2)}, "0_100", 2, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := prover.BatchVerify(append(proofs, other), scope, 2, 8); ok {
+		t.Fatal("batch verify should fail with a proof of another scope")
+	}
+	// 超出范围的数值
+	if _, err := prover.ProveBatch(context.Background(), [][]*big.Int{{big.NewInt(1), big.NewInt(256)}}, scope, 2, 8); err == nil {
+		t.Fatal("prove should fail with a value out of range")
+	}
+}
+
+func TestCachedStatement_Bounded(t *testing.T) {
+	defer func(limit int) { maxCachedStatements = limit }(maxCachedStatements)
+	maxCachedStatements = 2
+	first := cachedStatement("bounded 0", 1, 2)
+	cachedStatement("bounded 1", 1, 2)
+	// 最近使用过的参数不会被淘汰
+	if cachedStatement("bounded 0", 1, 2) != first {
+		t.Fatal("the statement is not cached")
+	}
+	for i := 2; i < 5; i++ {
+		cachedStatement(fmt.Sprintf("bounded %d", i), 1, 2)
+	}
+	statementsMu.Lock()
+	size := len(statements)
+	statementsMu.Unlock()
+	if size > maxCachedStatements {
+		t.Fatalf("%d statements are cached, the limit is %d", size, maxCachedStatements)
+	}
+}
+
+func TestProver_Cancel(t *testing.T) {
+	prover := NewProver(1, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := prover.Prove(ctx, []*big.Int{big.NewInt(1), big.NewInt(2)}, "test", 2, 8); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expect context canceled, got %v", err)
+	}
+	prover.Close()
+	if _, err := prover.Prove(context.Background(), []*big.Int{big.NewInt(1), big.NewInt(2)}, "test", 2, 8); !errors.Is(err, ErrProverClosed) {
+		t.Fatalf("expect prover closed, got %v", err)
+	}
+}
+
+func BenchmarkProverParallel(b *testing.B) {
+	prover := NewProver(0, 0)
+	defer prover.Close()
+	v := []*big.Int{big.NewInt(3), big.NewInt(66)}
+	scope := "1.000000000000000000000000000000_100.000000000000000000000000000000"
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := prover.Prove(context.Background(), v, scope, 2, 16); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	scope := "0_65535"
+	proofs := make([]string, 16)
+	for i := range proofs {
+		proofs[i], _ = GetRangeProof([]*big.Int{big.NewInt(int64(i)), big.NewInt(66)}, scope, 2, 16)
+	}
+	prover := NewProver(1, 0)
+	defer prover.Close()
+	b.Run("single", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, proof := range proofs {
+				_, _ = prover.Verify(proof, scope, 2, 16)
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = prover.BatchVerify(proofs, scope, 2, 16)
+		}
+	})
+}
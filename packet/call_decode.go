@@ -0,0 +1,180 @@
+package packet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	precompile "github.com/Venachain/client-sdk-go/precompiled"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/hexutil"
+	"github.com/Venachain/client-sdk-go/venachain/rlp"
+)
+
+// DecodedCall the decoded contract call of the transaction data
+type DecodedCall struct {
+	VmType  string         `json:"vmType"` // wasm or evm
+	TxType  uint64         `json:"txType,omitempty"`
+	CnsName string         `json:"cnsName,omitempty"` // the contract name when invoking by cns
+	Deploy  bool           `json:"deploy,omitempty"`
+	Method  string         `json:"method,omitempty"`
+	Params  []DecodedParam `json:"params,omitempty"`
+	Abi     *FuncDesc      `json:"-"`
+}
+
+// DecodedParam the decoded input parameter of the contract method
+type DecodedParam struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// PrecompiledContents returns the abi contents of the precompiled contracts,
+// the abi of the contract at the priorAddr (if any) comes first
+func PrecompiledContents(priorAddr string) []ContractContent {
+	addrs := make([]string, 0)
+	for addr := range precompile.List {
+		if IsMatch(addr, "address") && !strings.EqualFold(addr, priorAddr) {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Strings(addrs)
+	if _, ok := precompile.List[priorAddr]; ok {
+		addrs = append([]string{priorAddr}, addrs...)
+	}
+
+	contents := make([]ContractContent, 0)
+	for _, addr := range addrs {
+		abiBytes, err := precompile.GetContractByte(precompile.List[addr])
+		if err != nil {
+			continue
+		}
+		content, err := ParseAbiFromJson(abiBytes)
+		if err != nil {
+			continue
+		}
+		contents = append(contents, content)
+	}
+	return contents
+}
+
+// DecodeCallData decodes the transaction data against the contract contents provided,
+// the wasm data is matched by the rlp encoded function name and the evm data by the 4-byte selector
+func DecodeCallData(data []byte, to *common.Address, contents ...ContractContent) (*DecodedCall, error) {
+	if to == nil {
+		return decodeDeployData(data), nil
+	}
+	if call, ok, err := decodeWasmCallData(data, to, contents); ok {
+		return call, err
+	}
+	return decodeEvmCallData(data, contents)
+}
+
+func decodeDeployData(data []byte) *DecodedCall {
+	var dataList [][]byte
+	if err := rlp.DecodeBytes(data, &dataList); err == nil && len(dataList) == 3 && IsWasmContract(dataList[1]) {
+		return &DecodedCall{VmType: "wasm", TxType: uint64(common.BytesToInt64(dataList[0])), Deploy: true}
+	}
+	return &DecodedCall{VmType: "evm", Deploy: true}
+}
+
+// decodeWasmCallData returns false if the data is not in wasm format
+func decodeWasmCallData(data []byte, to *common.Address, contents []ContractContent) (*DecodedCall, bool, error) {
+	var dataList [][]byte
+	if err := rlp.DecodeBytes(data, &dataList); err != nil || len(dataList) < 2 || len(dataList[0]) != 8 {
+		return nil, false, nil
+	}
+
+	call := &DecodedCall{VmType: "wasm", TxType: uint64(common.BytesToInt64(dataList[0]))}
+	dataList = dataList[1:]
+	if strings.EqualFold(to.String(), precompile.CnsInvokeAddress) {
+		call.CnsName = string(dataList[0])
+		dataList = dataList[1:]
+		if len(dataList) == 0 {
+			return nil, true, errors.New("the function name of the cns invoking is empty")
+		}
+	}
+	call.Method = string(dataList[0])
+	params := dataList[1:]
+
+	for _, content := range contents {
+		for _, funcAbi := range content {
+			if funcAbi.Type != "function" || funcAbi.Name != call.Method || len(funcAbi.Inputs) != len(params) {
+				continue
+			}
+			call.Abi = funcAbi
+			for i, input := range funcAbi.Inputs {
+				call.Params = append(call.Params, DecodedParam{
+					Name:  input.Name,
+					Type:  input.Type,
+					Value: WasmBytesToValue(params[i], input.Type),
+				})
+			}
+			return call, true, nil
+		}
+	}
+
+	// the abi is not found, show the raw parameters
+	for _, p := range params {
+		call.Params = append(call.Params, DecodedParam{Type: "bytes", Value: hexutil.Encode(p)})
+	}
+	return call, true, fmt.Errorf("the abi of the function %s is not found", call.Method)
+}
+
+func decodeEvmCallData(data []byte, contents []ContractContent) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, errors.New("the data is neither wasm nor evm contract call")
+	}
+	evm := &EvmContractInterpreter{}
+	selector := hexutil.Encode(data[:4])
+
+	for _, content := range contents {
+		for _, funcAbi := range content {
+			if funcAbi.Type != "function" || hexutil.Encode(evm.encodeFuncName(funcAbi)) != selector {
+				continue
+			}
+			call := &DecodedCall{VmType: "evm", Method: funcAbi.Name, Abi: funcAbi}
+			if len(funcAbi.Inputs) == 0 {
+				return call, nil
+			}
+			values := GenUnpackArgs(funcAbi.Inputs).ReturnBytesUnpack(hexutil.Encode(data[4:]))
+			for i, input := range funcAbi.Inputs {
+				call.Params = append(call.Params, DecodedParam{Name: input.Name, Type: input.Type, Value: values[i]})
+			}
+			return call, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the function selector %s is not found", selector)
+}
+
+// WasmBytesToValue converts the wasm parameter bytes to the value of the abi type,
+// the bytes of the unsupported types are returned in hex
+func WasmBytesToValue(b []byte, typ string) interface{} {
+	switch typ {
+	case "string":
+		return string(b)
+	case "bool":
+		return len(b) == 1 && b[0] == 1
+	case "uint8", "uint16", "uint32", "uint64":
+		return new(big.Int).SetBytes(b).Uint64()
+	case "int8", "int16", "int32", "int64":
+		v := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return v.Int64()
+	case "float32":
+		if len(b) == 4 {
+			return math.Float32frombits(uint32(new(big.Int).SetBytes(b).Uint64()))
+		}
+	case "float64":
+		if len(b) == 8 {
+			return math.Float64frombits(new(big.Int).SetBytes(b).Uint64())
+		}
+	}
+	return hexutil.Encode(b)
+}
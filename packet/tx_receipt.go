@@ -76,9 +76,10 @@ type Receipt struct {
 }
 
 type Log struct {
-	Address string   `json:"address"`
-	Topics  []string `json:"topics"`
-	Data    string   `json:"data"`
+	Address     string   `json:"address"`
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+	BlockNumber string   `json:"blockNumber,omitempty"` // height of the block, set by eth_getLogs and log subscriptions
 }
 
 type RecptLogs []*Log
@@ -147,7 +148,7 @@ func GetSysEvents(SysEventList []string) []*FuncDesc {
 
 	for _, data := range SysEventList {
 		p := precompile.List[data]
-		abiBytes, _ := precompile.Asset(p)
+		abiBytes, _ := precompile.GetContractByte(p)
 		abiFunc, _ := ParseAbiFromJson(abiBytes)
 		events = append(events, abiFunc.GetEvents()...)
 	}
@@ -428,3 +429,36 @@ func RlpBytesToUint8(b []byte) uint8 {
 	result := common.CallResAsUint32(b)
 	return uint8(result)
 }
+
+// WasmEventTopic wasm 合约事件名对应的 topic
+func WasmEventTopic(name string) string {
+	return wasmLogTopicEncode(name)
+}
+
+// WasmEventData 按照事件参数的类型解析 wasm 合约事件日志的数据
+func WasmEventData(eLog *Log, types []string) ([]interface{}, error) {
+	var rlpList []interface{}
+	dataBytes, err := hexutil.Decode(eLog.Data)
+	if err != nil {
+		return nil, err
+	}
+	if err = rlp.DecodeBytes(dataBytes, &rlpList); err != nil {
+		return nil, err
+	}
+	if len(rlpList) != len(types) {
+		return nil, fmt.Errorf("the event data has %d fields, expect %d", len(rlpList), len(types))
+	}
+
+	result := make([]interface{}, 0, len(rlpList))
+	for i, v := range rlpList {
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("the event data field %d is not bytes", i)
+		}
+		if _, ok = Bytes2X_CMD[types[i]]; !ok {
+			return nil, fmt.Errorf("unsupported event data type %s", types[i])
+		}
+		result = append(result, ConvertRlpBytesTo(b, types[i]))
+	}
+	return result, nil
+}
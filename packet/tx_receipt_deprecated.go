@@ -16,7 +16,7 @@ type eventParsingFunc func(eLog *Log, abiBytes []byte) string
 func getSysEventAbis(SysEventList []string) (abiBytesArr [][]byte) {
 	for _, data := range SysEventList {
 		p := precompile.List[data]
-		abiBytes, _ := precompile.Asset(p)
+		abiBytes, _ := precompile.GetContractByte(p)
 		abiBytesArr = append(abiBytesArr, abiBytes)
 	}
 
@@ -2,6 +2,7 @@ package precompile
 
 import (
 	"bytes"
+	"embed"
 	"fmt"
 	"io"
 	"os"
@@ -41,7 +42,7 @@ var List = map[string]string{
 	ParameterManagementAddress:   "syscontracts/paramManager.cpp.abi.json",
 	FirewallManagementAddress:    "syscontracts/fireWall.abi.json",
 	GroupManagementAddress:       "syscontracts/groupManager.cpp.abi.json",
-	ContractDataProcessorAddress: "syscontracts/contractData.cpp.abi.json",
+	ContractDataProcessorAddress: "syscontracts/contractdata.cpp.abi.json",
 	NFTContractAddress:           "syscontracts/nft.abi.json",
 	EvidenceManagementAddress:    "syscontracts/evidenceManager.cpp.abi.json",
 	BulletProofAddress:           "syscontracts/RangeProof.cpp.abi.json",
@@ -80,7 +81,16 @@ func GetPreCompiledDirPath() string {
 	return getCurrentFilePath()
 }
 
+// 系统合约的 abi 文件编译在程序中，不依赖源码目录
+//
+//go:embed syscontracts/*.json
+var abiFiles embed.FS
+
+// GetContractByte 读取 List 中的 abi 文件，不在程序中的文件从当前源码目录读取
 func GetContractByte(jsonName string) ([]byte, error) {
+	if abiBytes, err := abiFiles.ReadFile(jsonName); err == nil {
+		return abiBytes, nil
+	}
 	parentFilePath := getCurrentFilePath()
 	objectPath := parentFilePath + jsonName
 	file, err := os.Open(objectPath)
@@ -97,3 +97,55 @@ type UserDescInfo struct {
 	Organization string `json:"organization,omitempty"`
 	Phone        string `json:"phone,omitempty"`
 }
+
+// CnsEntry cns 合约注册信息
+type CnsEntry struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Address    string `json:"address"`     // 合约地址
+	Origin     string `json:"origin"`      // 注册者地址
+	CreateTime uint64 `json:"create_time"` // 注册时间
+	Enabled    bool   `json:"enabled"`
+}
+
+// GroupInfo 群组信息
+type GroupInfo struct {
+	GroupID   uint64     `json:"groupID"`
+	Name      string     `json:"name,omitempty"`
+	Desc      string     `json:"desc,omitempty"`
+	Creator   string     `json:"creator,omitempty"` // 创建者地址
+	BootNodes []BootNode `json:"bootNodes"`
+}
+
+// BootNode 群组的引导节点
+type BootNode struct {
+	PublicKey  string `json:"publicKey,required"`
+	ExternalIP string `json:"externalIP,required"`
+	InternalIP string `json:"internalIP,omitempty"`
+	P2pPort    uint32 `json:"p2pPort,required"`
+}
+
+// NFTToken nft 合约中的 token 信息
+type NFTToken struct {
+	TokenID     string `json:"tokenId,omitempty"`
+	Name        string `json:"name"`
+	Symbol      string `json:"symbol"`
+	Description string `json:"description"`
+	IPrice      uint64 `json:"iprice"` // 发行价格
+	Price       uint64 `json:"price"`  // 当前价格
+	URL         string `json:"url"`
+	Property    string `json:"property"`
+	Others      string `json:"others"`
+	Owner       string `json:"owner,omitempty"`
+	Minter      string `json:"minter,omitempty"`
+}
+
+// NFTRecord nft 的流转记录
+type NFTRecord struct {
+	TokenID     string `json:"tokenId"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Price       uint64 `json:"price"`
+	Description string `json:"description"`
+	Time        uint64 `json:"time"`
+}
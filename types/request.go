@@ -15,4 +15,5 @@ var (
 	SendRawTransaction   = "eth_sendRawTransaction"
 	GetStorageAt         = "eth_getStorageAt"
 	GetLogs              = "eth_getLogs"
+	NetVersion           = "net_version"
 )
@@ -38,6 +38,7 @@ import (
 var (
 	ErrInvalidSig           = errors.New("invalid transaction v, r, s values")
 	ErrInvalidOldTrx        = errors.New("invalid old transaction payload")
+	ErrInvalidChainId       = errors.New("invalid chain id for signer")
 	TransactionsRlpCache, _ = lru.NewARC(4)
 )
 
@@ -155,11 +156,10 @@ func newTransaction(nonce uint64, to *common.Address, amount *big.Int, gasLimit
 	return &Transaction{data: d}
 }
 
-//
-//// ChainId returns which chain id this transaction was signed for (if at all)
-//func (tx *Transaction) ChainId() *big.Int {
-//	return deriveChainId(tx.data.V)
-//}
+// ChainId returns which chain id this transaction was signed for (if at all)
+func (tx *Transaction) ChainId() *big.Int {
+	return deriveChainId(tx.data.V)
+}
 
 // Protected returns whether the transaction is protected from replay protection.
 func (tx *Transaction) Protected() bool {
@@ -12,6 +12,89 @@ import (
 	"github.com/Venachain/client-sdk-go/venachain/rlp"
 )
 
+// MakeSigner returns the signer matching the signature of the given transaction,
+// EIP155Signer for replay protected transactions and HomesteadSigner otherwise
+func MakeSigner(tx *Transaction) Signer {
+	if tx.Protected() {
+		return NewEIP155Signer(tx.ChainId())
+	}
+	return HomesteadSigner{}
+}
+
+// EIP155Signer implements Signer using the EIP155 rules.
+type EIP155Signer struct {
+	chainId, chainIdMul *big.Int
+}
+
+func NewEIP155Signer(chainId *big.Int) EIP155Signer {
+	if chainId == nil {
+		chainId = new(big.Int)
+	}
+	return EIP155Signer{
+		chainId:    chainId,
+		chainIdMul: new(big.Int).Mul(chainId, big.NewInt(2)),
+	}
+}
+
+func (s EIP155Signer) Equal(s2 Signer) bool {
+	eip155, ok := s2.(EIP155Signer)
+	return ok && eip155.chainId.Cmp(s.chainId) == 0
+}
+
+func (s EIP155Signer) Sender(tx *Transaction) (common.Address, error) {
+	if !tx.Protected() {
+		return HomesteadSigner{}.Sender(tx)
+	}
+	if tx.ChainId().Cmp(s.chainId) != 0 {
+		return common.Address{}, ErrInvalidChainId
+	}
+	return RecoverPlain(s.Hash(tx), tx.data.R, tx.data.S, s.plainV(tx.data.V), true)
+}
+
+func (s EIP155Signer) SignatureAndSender(tx *Transaction) (common.Address, []byte, error) {
+	if !tx.Protected() {
+		return HomesteadSigner{}.SignatureAndSender(tx)
+	}
+	if tx.ChainId().Cmp(s.chainId) != 0 {
+		return common.Address{}, []byte{}, ErrInvalidChainId
+	}
+	return recoverPubKeyAndSender(s.Hash(tx), tx.data.R, tx.data.S, s.plainV(tx.data.V), true)
+}
+
+// SignatureValues returns signature values. This signature
+// needs to be in the [R || S || V] format where V is 0 or 1.
+func (s EIP155Signer) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big.Int, err error) {
+	R, S, V, err = HomesteadSigner{}.SignatureValues(tx, sig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if s.chainId.Sign() != 0 {
+		V = big.NewInt(int64(sig[64] + 35))
+		V.Add(V, s.chainIdMul)
+	}
+	return R, S, V, nil
+}
+
+// Hash returns the hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s EIP155Signer) Hash(tx *Transaction) common.Hash {
+	return rlpHash([]interface{}{
+		tx.data.AccountNonce,
+		tx.data.Price,
+		tx.data.GasLimit,
+		tx.data.Recipient,
+		tx.data.Amount,
+		tx.data.Payload,
+		s.chainId, uint(0), uint(0),
+	})
+}
+
+// plainV converts the EIP155 V value to the 27/28 form
+func (s EIP155Signer) plainV(v *big.Int) *big.Int {
+	V := new(big.Int).Sub(v, s.chainIdMul)
+	return V.Sub(V, big.NewInt(8))
+}
+
 // HomesteadTransaction implements TransactionInterface using the
 // homestead rules.
 type HomesteadSigner struct{ FrontierSigner }
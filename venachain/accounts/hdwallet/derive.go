@@ -0,0 +1,121 @@
+package hdwallet
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/Venachain/client-sdk-go/venachain/accounts"
+	"github.com/Venachain/client-sdk-go/venachain/common/math"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/tjfoc/gmsm/sm2"
+)
+
+// Curve 派生子私钥使用的椭圆曲线
+type Curve int
+
+const (
+	// Secp256k1 标准 BIP-32 派生
+	Secp256k1 Curve = iota
+	// SM2 在 SM2 曲线上按照 BIP-32 的规则派生，master key 使用独立的 hmac key，
+	// 与其他实现不保证兼容
+	SM2
+)
+
+const hardenedKeyStart = 0x80000000
+
+var (
+	errInvalidKey = errors.New("the derived key is invalid, try the next index")
+
+	masterHmacKey = map[Curve][]byte{
+		Secp256k1: []byte("Bitcoin seed"),
+		SM2:       []byte("SM2 seed"),
+	}
+)
+
+func (c Curve) elliptic() elliptic.Curve {
+	if c == SM2 {
+		return sm2.P256Sm2()
+	}
+	return crypto.S256()
+}
+
+func (c Curve) String() string {
+	if c == SM2 {
+		return "sm2"
+	}
+	return "secp256k1"
+}
+
+// extendedKey BIP-32 的扩展私钥
+type extendedKey struct {
+	curve     Curve
+	key       *big.Int
+	chainCode []byte
+}
+
+// newMasterKey 由种子生成 master key
+func newMasterKey(seed []byte, curve Curve) (*extendedKey, error) {
+	mac := hmac.New(sha512.New, masterHmacKey[curve])
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Sign() == 0 || key.Cmp(curve.elliptic().Params().N) >= 0 {
+		return nil, errInvalidKey
+	}
+	return &extendedKey{curve: curve, key: key, chainCode: sum[32:]}, nil
+}
+
+// child 派生第 index 个子私钥，index 不小于 0x80000000 时为强化派生
+func (k *extendedKey) child(index uint32) (*extendedKey, error) {
+	var data []byte
+	if index >= hardenedKeyStart {
+		data = append([]byte{0x00}, math.PaddedBigBytes(k.key, 32)...)
+	} else {
+		data = k.compressedPubKey()
+	}
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	data = append(data, indexBytes[:]...)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := k.curve.elliptic().Params().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, errInvalidKey
+	}
+	key := il.Add(il, k.key)
+	key.Mod(key, n)
+	if key.Sign() == 0 {
+		return nil, errInvalidKey
+	}
+	return &extendedKey{curve: k.curve, key: key, chainCode: sum[32:]}, nil
+}
+
+func (k *extendedKey) derive(path accounts.DerivationPath) (*extendedKey, error) {
+	key := k
+	for _, index := range path {
+		var err error
+		if key, err = key.child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func (k *extendedKey) publicKey() (*big.Int, *big.Int) {
+	return k.curve.elliptic().ScalarBaseMult(math.PaddedBigBytes(k.key, 32))
+}
+
+// compressedPubKey 33 字节的压缩公钥
+func (k *extendedKey) compressedPubKey() []byte {
+	x, y := k.publicKey()
+	return append([]byte{byte(0x02 + y.Bit(0))}, math.PaddedBigBytes(x, 32)...)
+}
@@ -0,0 +1,262 @@
+// Package hdwallet implements the BIP-32/BIP-39/BIP-44 hierarchical deterministic wallet.
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/Venachain/client-sdk-go/types"
+	"github.com/Venachain/client-sdk-go/venachain/accounts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/math"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/Venachain/client-sdk-go/venachain/keystore"
+	"github.com/pborman/uuid"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// Scheme HD 钱包 URL 的协议名
+const Scheme = "hd"
+
+var ErrUnknownAccount = errors.New("unknown account")
+
+var _ accounts.Wallet = (*Wallet)(nil)
+
+// Wallet BIP-32/BIP-39/BIP-44 分层确定性钱包，实现 accounts.Wallet 接口
+type Wallet struct {
+	mnemonic string
+	seed     []byte
+	master   *extendedKey
+
+	mu       sync.RWMutex
+	accounts []accounts.Account
+	paths    map[common.Address]accounts.DerivationPath
+}
+
+// NewMnemonic 生成 BIP-39 助记词，bitSize 为熵的位数，取值 128 到 256 之间 32 的倍数
+// 128 位对应 12 个单词，256 位对应 24 个单词
+func NewMnemonic(bitSize int) (string, error) {
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NewFromMnemonic 通过助记词恢复钱包，password 为 BIP-39 的可选密码
+func NewFromMnemonic(mnemonic, password string) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := NewFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	wallet.mnemonic = mnemonic
+	return wallet, nil
+}
+
+// NewFromSeed 通过种子创建钱包
+func NewFromSeed(seed []byte) (*Wallet, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("invalid seed length %d, should be between 16 and 64 bytes", len(seed))
+	}
+	master, err := newMasterKey(seed, Secp256k1)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		seed:   seed,
+		master: master,
+		paths:  make(map[common.Address]accounts.DerivationPath),
+	}, nil
+}
+
+// Mnemonic 钱包的助记词，通过种子创建的钱包为空
+func (w *Wallet) Mnemonic() string {
+	return w.mnemonic
+}
+
+// URL 以 master 公钥的哈希标识钱包
+func (w *Wallet) URL() accounts.URL {
+	return accounts.URL{
+		Scheme: Scheme,
+		Path:   hex.EncodeToString(crypto.Keccak256(w.master.compressedPubKey())[:8]),
+	}
+}
+
+func (w *Wallet) Status() (string, error) {
+	return "ok", nil
+}
+
+// Open HD 钱包不需要打开
+func (w *Wallet) Open(passphrase string) error {
+	return nil
+}
+
+func (w *Wallet) Close() error {
+	return nil
+}
+
+func (w *Wallet) Accounts() []accounts.Account {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	cpy := make([]accounts.Account, len(w.accounts))
+	copy(cpy, w.accounts)
+	return cpy
+}
+
+func (w *Wallet) Contains(account accounts.Account) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	_, ok := w.paths[account.Address]
+	return ok
+}
+
+// Derive 派生 secp256k1 账户，pin 为 true 时加入钱包的账户列表
+func (w *Wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	priv, err := w.derivePrivateKey(path)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	account := accounts.Account{
+		Address: crypto.PubkeyToAddress(priv.PublicKey),
+		URL:     accounts.URL{Scheme: Scheme, Path: w.URL().Path + "/" + path.String()},
+	}
+	if pin {
+		w.mu.Lock()
+		if _, ok := w.paths[account.Address]; !ok {
+			w.accounts = append(w.accounts, account)
+			w.paths[account.Address] = path
+		}
+		w.mu.Unlock()
+	}
+	return account, nil
+}
+
+// DeriveKey 派生 secp256k1 私钥，返回的 key 可以直接用于 NewClientWithKey
+func (w *Wallet) DeriveKey(path accounts.DerivationPath) (*keystore.Key, error) {
+	priv, err := w.derivePrivateKey(path)
+	if err != nil {
+		return nil, err
+	}
+	return &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(priv.PublicKey),
+		PrivateKey: priv,
+	}, nil
+}
+
+// DeriveKeys 沿着 base 路径递增最后一位，派生 n 个 secp256k1 私钥
+// 递增后的最后一位不能越过强化派生的边界，也不能溢出
+func (w *Wallet) DeriveKeys(base accounts.DerivationPath, n int) ([]*keystore.Key, error) {
+	if len(base) == 0 {
+		return nil, errors.New("the base derivation path is empty")
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("invalid number of keys %d", n)
+	}
+	if n > 0 {
+		first := uint64(base[len(base)-1])
+		limit := uint64(hardenedKeyStart)
+		if first >= hardenedKeyStart {
+			limit = 1 << 32
+		}
+		if first+uint64(n) > limit {
+			return nil, fmt.Errorf("deriving %d keys from %s exceeds the range of the last index", n, base)
+		}
+	}
+	keys := make([]*keystore.Key, 0, n)
+	for i := 0; i < n; i++ {
+		path := make(accounts.DerivationPath, len(base))
+		copy(path, base)
+		path[len(path)-1] += uint32(i)
+		key, err := w.DeriveKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Key 已加入钱包的账户对应的私钥
+func (w *Wallet) Key(account accounts.Account) (*keystore.Key, error) {
+	path, err := w.path(account)
+	if err != nil {
+		return nil, err
+	}
+	return w.DeriveKey(path)
+}
+
+// DeriveSM2Key 在 SM2 曲线上派生国密私钥
+// 注意：keystore.Key 只支持 ecdsa 私钥，国密私钥无法直接用于 NewClientWithKey
+func (w *Wallet) DeriveSM2Key(path accounts.DerivationPath) (crypto.PrivKey, error) {
+	master, err := newMasterKey(w.seed, SM2)
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.derive(path)
+	if err != nil {
+		return nil, err
+	}
+	x, y := key.publicKey()
+	return crypto.NewGMSMPrivKey(crypto.NewGMSMPubKey(x, y), key.key), nil
+}
+
+func (w *Wallet) derivePrivateKey(path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	key, err := w.master.derive(path)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(math.PaddedBigBytes(key.key, 32))
+}
+
+func (w *Wallet) path(account accounts.Account) (accounts.DerivationPath, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[account.Address]
+	if !ok {
+		return nil, ErrUnknownAccount
+	}
+	return path, nil
+}
+
+func (w *Wallet) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	key, err := w.Key(account)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, key.PrivateKey)
+}
+
+// SignTx chainID 为空或为 0 时使用 HomesteadSigner，与 SDK 其他签名方式一致
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	key, err := w.Key(account)
+	if err != nil {
+		return nil, err
+	}
+	var signer types.Signer = types.HomesteadSigner{}
+	if chainID != nil && chainID.Sign() != 0 {
+		signer = types.NewEIP155Signer(chainID)
+	}
+	return types.SignTx(tx, signer, key.PrivateKey)
+}
+
+// SignHashWithPassphrase HD 钱包不需要额外的密码
+func (w *Wallet) SignHashWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	return w.SignHash(account, hash)
+}
+
+// SignTxWithPassphrase HD 钱包不需要额外的密码
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.SignTx(account, tx, chainID)
+}
@@ -0,0 +1,121 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/types"
+	"github.com/Venachain/client-sdk-go/venachain/accounts"
+	"github.com/Venachain/client-sdk-go/venachain/common"
+	"github.com/Venachain/client-sdk-go/venachain/common/math"
+	"github.com/Venachain/client-sdk-go/venachain/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// BIP-32 test vector 1
+func TestBIP32Vector(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := newMasterKey(seed, Secp256k1)
+	assert.NoError(t, err)
+	assert.Equal(t, "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", hex.EncodeToString(math.PaddedBigBytes(master.key, 32)))
+	assert.Equal(t, "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508", hex.EncodeToString(master.chainCode))
+
+	cases := map[string]string{
+		"m/0'":                   "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":                 "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0'/1/2'":              "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		"m/0'/1/2'/2/1000000000": "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+	}
+	for p, want := range cases {
+		path, err := accounts.ParseDerivationPath(p)
+		assert.NoError(t, err)
+		key, err := master.derive(path)
+		assert.NoError(t, err)
+		assert.Equal(t, want, hex.EncodeToString(math.PaddedBigBytes(key.key, 32)), p)
+	}
+}
+
+func TestWalletFromMnemonic(t *testing.T) {
+	wallet, err := NewFromMnemonic(testMnemonic, "")
+	assert.NoError(t, err)
+
+	account, err := wallet.Derive(accounts.DefaultBaseDerivationPath, true)
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"), account.Address)
+	assert.True(t, wallet.Contains(account))
+	assert.Equal(t, 1, len(wallet.Accounts()))
+
+	key, err := wallet.Key(account)
+	assert.NoError(t, err)
+	assert.Equal(t, account.Address, key.Address)
+
+	keys, err := wallet.DeriveKeys(accounts.DefaultBaseDerivationPath, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, key.Address, keys[0].Address)
+	assert.NotEqual(t, keys[1].Address, keys[2].Address)
+
+	// 空路径以及越过强化派生边界或者溢出的路径
+	_, err = wallet.DeriveKeys(accounts.DerivationPath{}, 1)
+	assert.Error(t, err)
+	_, err = wallet.DeriveKeys(accounts.DerivationPath{0x80000000 + 44, hardenedKeyStart - 2}, 3)
+	assert.Error(t, err)
+	_, err = wallet.DeriveKeys(accounts.DerivationPath{0x80000000 + 44, 0xfffffffe}, 3)
+	assert.Error(t, err)
+	keys, err = wallet.DeriveKeys(accounts.DerivationPath{0x80000000 + 44, 0xfffffffe}, 2)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	_, err = NewFromMnemonic("abandon abandon", "")
+	assert.Error(t, err)
+}
+
+func TestWalletSign(t *testing.T) {
+	mnemonic, err := NewMnemonic(128)
+	assert.NoError(t, err)
+	wallet, err := NewFromMnemonic(mnemonic, "password")
+	assert.NoError(t, err)
+	account, err := wallet.Derive(accounts.DefaultBaseDerivationPath, true)
+	assert.NoError(t, err)
+
+	tx := types.NewTransaction(1, common.HexToAddress("0x0000000000000000000000000000000000000099"), big.NewInt(0), 0, big.NewInt(0), nil)
+	signed, err := wallet.SignTx(account, tx, nil)
+	assert.NoError(t, err)
+	from, err := types.Sender(types.HomesteadSigner{}, signed)
+	assert.NoError(t, err)
+	assert.Equal(t, account.Address, from)
+
+	signed, err = wallet.SignTx(account, tx, big.NewInt(300))
+	assert.NoError(t, err)
+	from, err = types.Sender(types.NewEIP155Signer(big.NewInt(300)), signed)
+	assert.NoError(t, err)
+	assert.Equal(t, account.Address, from)
+
+	hash := crypto.Keccak256([]byte("hello"))
+	sig, err := wallet.SignHash(account, hash)
+	assert.NoError(t, err)
+	pub, err := crypto.SigToPub(hash, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, account.Address, crypto.PubkeyToAddress(*pub))
+
+	_, err = wallet.SignHash(accounts.Account{}, hash)
+	assert.Equal(t, ErrUnknownAccount, err)
+}
+
+func TestDeriveSM2Key(t *testing.T) {
+	wallet, err := NewFromMnemonic(testMnemonic, "")
+	assert.NoError(t, err)
+	a, err := wallet.DeriveSM2Key(accounts.DefaultBaseDerivationPath)
+	assert.NoError(t, err)
+	b, err := wallet.DeriveSM2Key(accounts.DefaultBaseDerivationPath)
+	assert.NoError(t, err)
+	assert.True(t, a.Equals(b))
+	assert.True(t, a.GetPubKey().IsOnCurve(a.GetPubKey().GetX(), a.GetPubKey().GetY()))
+
+	path, _ := accounts.ParseDerivationPath("m/44'/60'/0'/0/1")
+	c, err := wallet.DeriveSM2Key(path)
+	assert.NoError(t, err)
+	assert.False(t, a.Equals(c))
+}
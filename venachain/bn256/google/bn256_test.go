@@ -309,3 +309,18 @@ func BenchmarkPairing(b *testing.B) {
 		Pair(&G1{curveGen}, &G2{twistGen})
 	}
 }
+
+func TestG1ScalarMultNegative(t *testing.T) {
+	k, _ := rand.Int(rand.Reader, Order)
+	a := new(G1).ScalarBaseMult(k)
+	neg := new(G1).Neg(new(G1).ScalarMult(a, k))
+	got := new(G1).ScalarMult(a, new(big.Int).Neg(k))
+	if !bytes.Equal(got.Marshal(), neg.Marshal()) {
+		t.Errorf("a*(-k) != -(a*k)")
+	}
+	// a negative scalar acts like its residue modulo Order
+	reduced := new(G1).ScalarMult(a, new(big.Int).Sub(Order, k))
+	if !bytes.Equal(got.Marshal(), reduced.Marshal()) {
+		t.Errorf("a*(-k) != a*(Order-k)")
+	}
+}
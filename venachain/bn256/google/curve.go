@@ -226,6 +226,10 @@ func (c *curvePoint) Double(a *curvePoint, pool *bnPool) {
 }
 
 func (c *curvePoint) Mul(a *curvePoint, scalar *big.Int, pool *bnPool) *curvePoint {
+	// the double-and-add loop below only handles non-negative scalars
+	if scalar.Sign() < 0 {
+		scalar = new(big.Int).Mod(scalar, Order)
+	}
 	sum := newCurvePoint(pool)
 	sum.SetInfinity()
 	t := newCurvePoint(pool)
@@ -687,8 +687,7 @@ func VerifyAggIpp(proof *AggBulletProof, instance *AggBpStatement) (bool, error)
 }
 
 //only for withdrawproof
-func NewAggBpStatement(m int64, aggbppram AggBpStatement) *AggBpStatement {
-	var aggbpparam AggBpStatement
+func NewAggBpStatement(m int64, aggbpparam AggBpStatement) *AggBpStatement {
 	result := &AggBpStatement{
 		m:       m,
 		bpParam: BulletProofParams{},
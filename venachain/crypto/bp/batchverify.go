@@ -0,0 +1,178 @@
+package bp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/Venachain/client-sdk-go/venachain/bn256"
+)
+
+/*
+AggBpBatchVerify verifies many aggregate bulletproofs of the same statement at once.
+For every proof the tHat equation and the inner product equation are moved to one side
+(so each of them must equal zero), multiplied by independent random weights and added up.
+The generators g, h, gVector and hVector of the statement are shared by all proofs, so the
+whole batch is checked with a single multi-exponentiation. The batch passes only if every
+proof is valid, except with negligible probability; when it fails, use AggBpVerify to find
+the invalid proofs.
+*/
+func AggBpBatchVerify(proofs []*AggBulletProof, instance *AggBpStatement) (bool, error) {
+	n := instance.bpParam.n
+	m := instance.m
+	nm := n * m
+	if len(proofs) == 0 {
+		return true, nil
+	}
+	if nm <= 0 || !IsPowOfTwo(nm) || int64(len(instance.bpParam.gVector)) != nm || int64(len(instance.bpParam.hVector)) != nm {
+		return false, errors.New("invalid statement")
+	}
+	nLog := 0
+	for l := nm; l > 1; l >>= 1 {
+		nLog++
+	}
+
+	gScalar := big.NewInt(0)
+	hScalar := big.NewInt(0)
+	gVectorScalars := make([]*big.Int, nm)
+	hVectorScalars := make([]*big.Int, nm)
+	for i := range gVectorScalars {
+		gVectorScalars[i] = big.NewInt(0)
+		hVectorScalars[i] = big.NewInt(0)
+	}
+	var points []*bn256.G1
+	var scalars []*big.Int
+	addTerm := func(p *bn256.G1, k *big.Int) {
+		points = append(points, p)
+		scalars = append(scalars, new(big.Int).Mod(k, ORDER))
+	}
+	mulMod := func(a, b *big.Int) *big.Int {
+		return new(big.Int).Mod(new(big.Int).Mul(a, b), ORDER)
+	}
+	twon := PowerOf(big.NewInt(2), n)
+
+	for _, proof := range proofs {
+		if proof == nil || proof.A == nil || proof.S == nil || proof.T1 == nil || proof.T2 == nil ||
+			proof.taux == nil || proof.mu == nil || proof.tHat == nil ||
+			proof.innerProductProof == nil || proof.innerProductProof.a == nil || proof.innerProductProof.b == nil {
+			return false, errors.New("invalid proof")
+		}
+		ipp := proof.innerProductProof
+		if int64(len(proof.V)) != m || len(ipp.LS) != nLog || len(ipp.RS) != nLog {
+			return false, errVectorLength
+		}
+		// random weights of the tHat equation and the inner product equation
+		d, err := rand.Int(rand.Reader, ORDER)
+		if err != nil {
+			return false, err
+		}
+		c, err := rand.Int(rand.Reader, ORDER)
+		if err != nil {
+			return false, err
+		}
+		y, z := GenerateAggyz(proof.A, proof.S)
+		x := Generatex(proof.T1, proof.T2, z)
+		xx := mulMod(x, x)
+
+		// d * (g*(tHat-delta) + h*taux - sum(V[j]*z^(j+2)) - T1*x - T2*x^2) = 0
+		delta := ComputeAggDelta(y, z, n, m)
+		gScalar.Add(gScalar, mulMod(d, new(big.Int).Sub(proof.tHat, delta)))
+		hScalar.Add(hScalar, mulMod(d, proof.taux))
+		zj := mulMod(z, z)
+		for j := int64(0); j < m; j++ {
+			addTerm(proof.V[j], new(big.Int).Neg(mulMod(d, zj)))
+			zj = mulMod(zj, z)
+		}
+		addTerm(proof.T1, new(big.Int).Neg(mulMod(d, x)))
+		addTerm(proof.T2, new(big.Int).Neg(mulMod(d, xx)))
+
+		// challenges of the inner product proof, see Protocol1 and InnerProductVerifier
+		dig := sha256.Sum256(x.Bytes())
+		ch := hashToInt(dig[:], BN256())
+		ch.Mod(ch, ORDER)
+		chVector := make([]*big.Int, nLog)
+		chInv := make([]*big.Int, nLog)
+		prev := ch
+		for j := 0; j < nLog; j++ {
+			chVector[j] = Generatex(ipp.LS[j], ipp.RS[j], prev)
+			chInv[j] = new(big.Int).ModInverse(chVector[j], ORDER)
+			if chInv[j] == nil {
+				return false, errors.New("invalid proof")
+			}
+			prev = chVector[j]
+		}
+		yInv := new(big.Int).ModInverse(y, ORDER)
+		yInvn := PowerOf(yInv, nm)
+
+		/*
+			c * (a*s*gVector + b*s^-1*hprime + u*ch*(a*b-tHat) - P - sum(LS[j]*x[j]^2 + RS[j]*x[j]^-2)) = 0
+			where P = A + x*S - z*gVector + hprime*(z*y^i + z^(j+2)*2^i) - mu*h and hprime[i] = hVector[i]*y^-i
+		*/
+		zPow := make([]*big.Int, m)
+		zPow[0] = mulMod(z, z)
+		for j := int64(1); j < m; j++ {
+			zPow[j] = mulMod(zPow[j-1], z)
+		}
+		for i := int64(1); i <= nm; i++ {
+			s := big.NewInt(1)
+			for j := 1; j <= nLog; j++ {
+				bij, err := Bij(uint64(i), uint64(nLog+1-j))
+				if err != nil {
+					return false, err
+				}
+				if bij == 1 {
+					s = mulMod(s, chVector[j-1])
+				} else {
+					s = mulMod(s, chInv[j-1])
+				}
+			}
+			sInv := new(big.Int).ModInverse(s, ORDER)
+			gk := new(big.Int).Add(mulMod(ipp.a, s), z)
+			gVectorScalars[i-1].Add(gVectorScalars[i-1], mulMod(c, gk))
+			// b*s^-1*y^-i - z - z^(j+2)*2^i*y^-i
+			hk := mulMod(mulMod(ipp.b, sInv), yInvn[i-1])
+			hk.Sub(hk, z)
+			hk.Sub(hk, mulMod(mulMod(zPow[(i-1)/n], twon[(i-1)%n]), yInvn[i-1]))
+			hVectorScalars[i-1].Add(hVectorScalars[i-1], mulMod(c, hk))
+		}
+		u := MapIntoGroup(x.String())
+		ab := mulMod(ipp.a, ipp.b)
+		addTerm(u, mulMod(c, mulMod(ch, new(big.Int).Sub(ab, proof.tHat))))
+		addTerm(proof.A, new(big.Int).Neg(c))
+		addTerm(proof.S, new(big.Int).Neg(mulMod(c, x)))
+		hScalar.Add(hScalar, mulMod(c, proof.mu))
+		for j := 0; j < nLog; j++ {
+			xj2 := mulMod(chVector[j], chVector[j])
+			xjInv2 := mulMod(chInv[j], chInv[j])
+			addTerm(ipp.LS[j], new(big.Int).Neg(mulMod(c, xj2)))
+			addTerm(ipp.RS[j], new(big.Int).Neg(mulMod(c, xjInv2)))
+		}
+	}
+
+	addTerm(instance.bpParam.g, gScalar)
+	addTerm(instance.bpParam.h, hScalar)
+	for i := int64(0); i < nm; i++ {
+		addTerm(instance.bpParam.gVector[i], gVectorScalars[i])
+		addTerm(instance.bpParam.hVector[i], hVectorScalars[i])
+	}
+	res, err := MultiExp(points, scalars)
+	if err != nil {
+		return false, err
+	}
+	zeroPoint := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	return string(res.Marshal()) == string(zeroPoint.Marshal()), nil
+}
+
+// the proofs input are strings
+func AggBpBatchVerify_s(proofs []string, instance *AggBpStatement) (bool, error) {
+	aggproofs := make([]*AggBulletProof, len(proofs))
+	for i, proof := range proofs {
+		aggproof, err := AggProofUnMarshal(proof)
+		if err != nil {
+			return false, err
+		}
+		aggproofs[i] = aggproof
+	}
+	return AggBpBatchVerify(aggproofs, instance)
+}
@@ -0,0 +1,42 @@
+package bp
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestAggBpBatchVerify(t *testing.T) {
+	statement := GenerateAggBpStatement_range(2, 8, []byte("batch verify"))
+	var proofs []*AggBulletProof
+	for i := int64(0); i < 4; i++ {
+		proof, err := AggBpProve(statement, NewAggBpWitness([]*big.Int{big.NewInt(i), big.NewInt(255 - i)}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs = append(proofs, proof)
+	}
+	if ok, err := AggBpBatchVerify(proofs, statement); !ok || err != nil {
+		t.Fatalf("batch verify failed: %v", err)
+	}
+
+	// 任意一个证明被篡改时整批验证失败
+	tamper := []func(proof *AggBulletProof){
+		func(proof *AggBulletProof) { proof.tHat = new(big.Int).Add(proof.tHat, big.NewInt(1)) },
+		func(proof *AggBulletProof) { proof.taux = new(big.Int).Add(proof.taux, big.NewInt(1)) },
+		func(proof *AggBulletProof) { proof.mu = new(big.Int).Add(proof.mu, big.NewInt(1)) },
+	}
+	for i, modify := range tamper {
+		tampered := *proofs[2]
+		modify(&tampered)
+		batch := append(append([]*AggBulletProof{}, proofs...), &tampered)
+		if ok, _ := AggBpBatchVerify(batch, statement); ok {
+			t.Fatalf("batch verify should fail with the tampered proof %d", i)
+		}
+		if ok, _ := AggBpVerify(&tampered, statement); ok {
+			t.Fatalf("verify should fail with the tampered proof %d", i)
+		}
+	}
+	if _, err := AggBpBatchVerify([]*AggBulletProof{nil}, statement); err == nil {
+		t.Fatal("expect invalid proof error")
+	}
+}
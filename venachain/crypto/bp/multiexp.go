@@ -0,0 +1,69 @@
+package bp
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/Venachain/client-sdk-go/venachain/bn256"
+)
+
+/*
+MultiExp computes sum(scalars[i] * points[i]) with the bucket method of Pippenger.
+The scalars are split into windows of c bits, in each window the points are added into
+2^c-1 buckets by their window value, so every point costs one addition per window instead
+of a full scalar multiplication.
+*/
+func MultiExp(points []*bn256.G1, scalars []*big.Int) (*bn256.G1, error) {
+	if len(points) != len(scalars) {
+		return nil, errVectorLength
+	}
+	n := len(points)
+	ks := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		ks[i] = new(big.Int).Mod(scalars[i], ORDER)
+	}
+	if n < 4 {
+		res := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+		for i := 0; i < n; i++ {
+			res = new(bn256.G1).Add(res, new(bn256.G1).ScalarMult(points[i], ks[i]))
+		}
+		return res, nil
+	}
+	c := bits.Len(uint(n)) - 2
+	if c > 16 {
+		c = 16
+	}
+	windows := (ORDER.BitLen() + c - 1) / c
+	res := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	for w := windows - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			res = new(bn256.G1).Add(res, res)
+		}
+		buckets := make([]*bn256.G1, 1<<uint(c))
+		for i := 0; i < n; i++ {
+			idx := 0
+			for b := c - 1; b >= 0; b-- {
+				idx = idx<<1 | int(ks[i].Bit(w*c+b))
+			}
+			if idx == 0 {
+				continue
+			}
+			if buckets[idx] == nil {
+				buckets[idx] = points[i]
+			} else {
+				buckets[idx] = new(bn256.G1).Add(buckets[idx], points[i])
+			}
+		}
+		// sum(idx * buckets[idx]) = sum of the running sums from the highest bucket
+		running := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+		sum := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+		for idx := len(buckets) - 1; idx > 0; idx-- {
+			if buckets[idx] != nil {
+				running = new(bn256.G1).Add(running, buckets[idx])
+			}
+			sum = new(bn256.G1).Add(sum, running)
+		}
+		res = new(bn256.G1).Add(res, sum)
+	}
+	return res, nil
+}
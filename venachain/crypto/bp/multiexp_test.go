@@ -0,0 +1,47 @@
+package bp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/Venachain/client-sdk-go/venachain/bn256"
+)
+
+func naiveMultiExp(points []*bn256.G1, scalars []*big.Int) *bn256.G1 {
+	res := new(bn256.G1).ScalarBaseMult(big.NewInt(0))
+	for i := range points {
+		res = new(bn256.G1).Add(res, new(bn256.G1).ScalarMult(points[i], scalars[i]))
+	}
+	return res
+}
+
+func TestMultiExp(t *testing.T) {
+	// n < 4 时直接相加，n >= 4 时使用 Pippenger 的分桶方法
+	for _, n := range []int{0, 1, 3, 4, 5, 17, 64} {
+		points := make([]*bn256.G1, n)
+		scalars := make([]*big.Int, n)
+		for i := 0; i < n; i++ {
+			k, _ := rand.Int(rand.Reader, ORDER)
+			points[i] = new(bn256.G1).ScalarBaseMult(k)
+			scalars[i], _ = rand.Int(rand.Reader, ORDER)
+		}
+		if n > 4 {
+			// 0、重复的点和大于阶的标量
+			scalars[0] = big.NewInt(0)
+			points[1] = points[2]
+			scalars[3] = new(big.Int).Add(ORDER, big.NewInt(7))
+		}
+		res, err := MultiExp(points, scalars)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(res.Marshal(), naiveMultiExp(points, scalars).Marshal()) {
+			t.Fatalf("the multi exponentiation of %d points does not match the naive sum", n)
+		}
+	}
+	if _, err := MultiExp([]*bn256.G1{new(bn256.G1).ScalarBaseMult(big.NewInt(1))}, nil); err == nil {
+		t.Fatal("expect vector length error")
+	}
+}
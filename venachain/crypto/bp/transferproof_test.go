@@ -53,7 +53,7 @@ func TestCommitToBits(t *testing.T) {
 	tfWit.l0 = big.NewInt(2)
 	tfWit.l1 = big.NewInt(3)
 	agBp := AggBp()
-	A, B, _, _, a, b, _ := commitToBits(m, tfWit, &agBp)
+	A, B, _, _, a, b, _ := commitToBits(m, tfWit, agBp)
 	t.Log(A)
 	t.Log(B)
 	t.Log(len(a))
@@ -419,7 +419,7 @@ func TestComputeAt(t *testing.T) {
 	m := int64(4)
 	T1 := new(bn256.G1).ScalarMult(G, big.NewInt(10))
 	T2 := new(bn256.G1).ScalarMult(G, big.NewInt(2))
-	res := ComputeAt(&aggBp, c, omega, tHat, stau, delta, sb, x, m, T1, T2)
+	res := ComputeAt(aggBp, c, omega, tHat, stau, delta, sb, x, m, T1, T2)
 	t.Log(res)
 	testRes := new(bn256.G1).Add(new(bn256.G1).ScalarMult(G, big.NewInt(-17931)), new(bn256.G1).ScalarMult(aggBp.bpParam.h, stau))
 	t.Log(testRes)
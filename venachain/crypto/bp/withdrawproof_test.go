@@ -81,3 +81,46 @@ func TestWithdrawProve2(t *testing.T) {
 	fmt.Println(res)
 
 }
+
+func TestNewAggBpStatement(t *testing.T) {
+	param := AggBp()
+	statement := NewAggBpStatement(1, *param)
+	if statement.m != 1 || statement.bpParam.n != param.bpParam.n {
+		t.Fatalf("unexpected statement size m=%d n=%d", statement.m, statement.bpParam.n)
+	}
+	if len(statement.bpParam.gVector) != int(param.bpParam.n) || len(statement.bpParam.hVector) != int(param.bpParam.n) {
+		t.Fatalf("unexpected generator length %d %d", len(statement.bpParam.gVector), len(statement.bpParam.hVector))
+	}
+	if statement.bpParam.g == nil || statement.bpParam.g.String() != param.bpParam.g.String() || statement.bpParam.h.String() != param.bpParam.h.String() {
+		t.Fatal("the generators are not copied from the source statement")
+	}
+
+	// 使用派生的参数证明并验证
+	proof, err := AggBpProve(statement, &AggBpWitness{v: []*big.Int{big.NewInt(10)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := AggBpVerify(proof, statement)
+	if err != nil || !res {
+		t.Fatalf("verify the proof of the withdraw statement failed: %v", err)
+	}
+
+	// 提现证明序列化后仍然可以验证
+	key, _ := NewKeyPair(rand.Reader)
+	c, _ := Enc(rand.Reader, key.pk, big.NewInt(10))
+	epoch := big.NewInt(4)
+	sender := []byte{1, 2, 3, 4, 5}
+	wdProof, err := WithdrawProve(c.C, c.D, key.pk, epoch, sender, &WithdrawWitness{priv: key.sk, vDiff: &AggBpWitness{v: []*big.Int{big.NewInt(10)}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := WdProofUnMarshal(wdProof.WdProofMarshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := new(bn256.G1).ScalarMult(MapIntoGroup("zether"+epoch.String()), key.sk)
+	res, err = WithdrawVerify(&WithdrawStatement{c.C, c.D, key.pk, epoch, sender, u}, decoded)
+	if err != nil || !res {
+		t.Fatalf("verify the decoded withdraw proof failed: %v", err)
+	}
+}